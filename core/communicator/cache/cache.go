@@ -0,0 +1,24 @@
+// Package cache provides the pluggable KV-store abstraction
+// deviceClassCommunicator memoizes detection and readout results against, so
+// devices that are polled repeatedly (e.g. once a minute across thousands of
+// targets) don't re-run the same SNMP queries for values that rarely change.
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Cache is a flat KV store with per-key TTLs. Redis and etcd backends are
+// provided, alongside a Noop backend used when caching is disabled.
+type Cache interface {
+	// Get returns the cached value for key, and false if it wasn't found
+	// (either never written, or expired).
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Put stores value under key with the given TTL. A TTL of 0 means the
+	// value never expires on its own.
+	Put(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Watch streams updates to key until ctx is canceled. Redis has no
+	// native push support and emulates it by polling; Noop never sends.
+	Watch(ctx context.Context, key string) (<-chan []byte, error)
+}
@@ -0,0 +1,37 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestNoopRoundTrip(t *testing.T) {
+	var c Cache = Noop{}
+	ctx := context.Background()
+
+	if err := c.Put(ctx, "key", []byte("value"), time.Minute); err != nil {
+		t.Fatalf("Put returned an error: %v", err)
+	}
+	if _, found, err := c.Get(ctx, "key"); err != nil || found {
+		t.Fatalf("Get on Noop = (_, %v, %v), want (_, false, nil)", found, err)
+	}
+}
+
+func TestNewCache(t *testing.T) {
+	ctx := context.Background()
+
+	for _, backend := range []string{"", "none"} {
+		c, err := NewCache(ctx, BackendConfig{Backend: backend})
+		if err != nil {
+			t.Fatalf("NewCache(%q) returned an error: %v", backend, err)
+		}
+		if _, ok := c.(Noop); !ok {
+			t.Fatalf("NewCache(%q) = %T, want Noop", backend, c)
+		}
+	}
+
+	if _, err := NewCache(ctx, BackendConfig{Backend: "bogus"}); err == nil {
+		t.Fatal("NewCache with an unknown backend should return an error")
+	}
+}
@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Default TTLs used when a BackendConfig leaves the corresponding field
+// unset.
+const (
+	DefaultDeviceClassTTL = 24 * time.Hour
+	DefaultIdentifyTTL    = time.Hour
+	DefaultIfTableTTL     = time.Minute
+)
+
+// BackendConfig selects and configures which Cache backend
+// deviceClassCommunicator memoizes detection and readout results against.
+// It is passed to communicator.NewDeviceClassCommunicator by whatever sets
+// up a communicator for a target (there is no CLI flag wiring it up yet);
+// the zero value disables caching.
+type BackendConfig struct {
+	// Backend selects the Cache implementation: "none" (default), "redis"
+	// or "etcd".
+	Backend string
+	// Address is a single "host:port" for the redis backend, or a
+	// comma-separated list of "host:port" endpoints for the etcd backend.
+	Address string
+
+	// DeviceClassTTL bounds how long a resolved device class is memoized per
+	// target host (see communicator.CacheDeviceClassName /
+	// CachedDeviceClassName).
+	DeviceClassTTL time.Duration
+	// IdentifyTTL bounds how long static identify properties (vendor,
+	// model, serial number, ...) are memoized per target host.
+	IdentifyTTL time.Duration
+	// IfTableTTL bounds how long the last successful GetIfTable result is
+	// kept around to smooth over transient SNMP timeouts.
+	IfTableTTL time.Duration
+}
+
+// NewCache builds the Cache backend selected by config.Backend.
+func NewCache(ctx context.Context, config BackendConfig) (Cache, error) {
+	switch config.Backend {
+	case "", "none":
+		return Noop{}, nil
+	case "redis":
+		return NewRedis(ctx, RedisConfig{Address: config.Address})
+	case "etcd":
+		return NewEtcd(EtcdConfig{Endpoints: strings.Split(config.Address, ",")})
+	default:
+		return nil, errors.Errorf("unknown cache backend '%s'", config.Backend)
+	}
+}
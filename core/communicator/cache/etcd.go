@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// EtcdConfig configures the etcd-backed Cache.
+type EtcdConfig struct {
+	Endpoints   []string
+	DialTimeout time.Duration
+}
+
+// Etcd is a Cache backed by an etcd cluster. Unlike Redis, it supports
+// native Watch via etcd's own watch API instead of polling.
+type Etcd struct {
+	client *clientv3.Client
+}
+
+// NewEtcd creates an etcd-backed Cache.
+func NewEtcd(config EtcdConfig) (*Etcd, error) {
+	dialTimeout := config.DialTimeout
+	if dialTimeout <= 0 {
+		dialTimeout = 5 * time.Second
+	}
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   config.Endpoints,
+		DialTimeout: dialTimeout,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to etcd")
+	}
+	return &Etcd{client: client}, nil
+}
+
+// Get reads key from etcd.
+func (e *Etcd) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	resp, err := e.client.Get(ctx, key)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to read from etcd")
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, false, nil
+	}
+	return resp.Kvs[0].Value, true, nil
+}
+
+// Put writes key to etcd, attaching a lease for the given TTL when it is
+// non-zero.
+func (e *Etcd) Put(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if ttl <= 0 {
+		_, err := e.client.Put(ctx, key, string(value))
+		return errors.Wrap(err, "failed to write to etcd")
+	}
+
+	lease, err := e.client.Grant(ctx, int64(ttl.Seconds()))
+	if err != nil {
+		return errors.Wrap(err, "failed to create etcd lease")
+	}
+	_, err = e.client.Put(ctx, key, string(value), clientv3.WithLease(lease.ID))
+	return errors.Wrap(err, "failed to write to etcd")
+}
+
+// Watch streams native etcd watch events for key.
+func (e *Etcd) Watch(ctx context.Context, key string) (<-chan []byte, error) {
+	ch := make(chan []byte)
+	watchChan := e.client.Watch(ctx, key)
+	go func() {
+		defer close(ch)
+		for resp := range watchChan {
+			for _, ev := range resp.Events {
+				select {
+				case ch <- ev.Kv.Value:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return ch, nil
+}
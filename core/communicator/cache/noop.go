@@ -0,0 +1,31 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Noop is a Cache that never stores anything. It is the default backend, so
+// enabling the cache layer is strictly opt-in and behavior is unchanged when
+// it is left disabled.
+type Noop struct{}
+
+// Get always reports a miss.
+func (Noop) Get(_ context.Context, _ string) ([]byte, bool, error) {
+	return nil, false, nil
+}
+
+// Put is a no-op.
+func (Noop) Put(_ context.Context, _ string, _ []byte, _ time.Duration) error {
+	return nil
+}
+
+// Watch returns a channel that only ever closes when ctx is done.
+func (Noop) Watch(ctx context.Context, _ string) (<-chan []byte, error) {
+	ch := make(chan []byte)
+	go func() {
+		<-ctx.Done()
+		close(ch)
+	}()
+	return ch, nil
+}
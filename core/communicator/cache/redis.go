@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
+)
+
+// RedisConfig configures the Redis-backed Cache.
+type RedisConfig struct {
+	Address  string
+	Password string
+	DB       int
+}
+
+// Redis is a Cache backed by a single Redis instance/cluster endpoint.
+type Redis struct {
+	client *redis.Client
+}
+
+// NewRedis creates a Redis-backed Cache and verifies connectivity.
+func NewRedis(ctx context.Context, config RedisConfig) (*Redis, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:     config.Address,
+		Password: config.Password,
+		DB:       config.DB,
+	})
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to connect to redis")
+	}
+	return &Redis{client: client}, nil
+}
+
+// Get reads key from Redis.
+func (r *Redis) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	val, err := r.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to read from redis")
+	}
+	return val, true, nil
+}
+
+// Put writes key to Redis with the given TTL.
+func (r *Redis) Put(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := r.client.Set(ctx, key, value, ttl).Err(); err != nil {
+		return errors.Wrap(err, "failed to write to redis")
+	}
+	return nil
+}
+
+// Watch polls Redis for changes to key, since plain Redis keys have no
+// native watch primitive (that requires broker-side keyspace notifications
+// thola shouldn't assume are configured). Callers that need push semantics
+// should use the etcd backend instead.
+func (r *Redis) Watch(ctx context.Context, key string) (<-chan []byte, error) {
+	ch := make(chan []byte)
+	go func() {
+		defer close(ch)
+		var last []byte
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				val, ok, err := r.Get(ctx, key)
+				if err != nil || !ok || string(val) == string(last) {
+					continue
+				}
+				last = val
+				select {
+				case ch <- val:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return ch, nil
+}
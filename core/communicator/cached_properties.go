@@ -0,0 +1,111 @@
+package communicator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/inexio/thola/core/communicator/cache"
+	"github.com/inexio/thola/core/device"
+	"github.com/inexio/thola/core/network"
+	"github.com/rs/zerolog/log"
+)
+
+// cacheKey builds the (host, community/credentials-hash, device class,
+// property) key that identify and ifTable readouts are memoized under, so
+// neither two targets sharing a host but polled with different credentials,
+// nor two device classes probed against the same host during detection,
+// ever read back each other's cached values.
+func (o *deviceClassCommunicator) cacheKey(ctx context.Context, property string) (string, bool) {
+	if o.cache == nil {
+		return "", false
+	}
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.SNMP == nil {
+		return "", false
+	}
+	return fmt.Sprintf("thola:%s:%s:%s:%s", con.Host, con.SNMP.CredentialsHash(), o.name, property), true
+}
+
+// cacheGetString reads a cached string property, reporting false if caching
+// is disabled or the key isn't present.
+func (o *deviceClassCommunicator) cacheGetString(ctx context.Context, property string) (string, bool) {
+	key, ok := o.cacheKey(ctx, property)
+	if !ok {
+		return "", false
+	}
+	val, found, err := o.cache.Get(ctx, key)
+	if err != nil {
+		log.Ctx(ctx).Trace().Err(err).Str("property", property).Msg("failed to read property from cache")
+		return "", false
+	}
+	if !found {
+		return "", false
+	}
+	return string(val), true
+}
+
+// cachePutString writes a string property to the cache with the given TTL,
+// logging but otherwise ignoring failures - the cache is an optimization,
+// never a source of truth.
+func (o *deviceClassCommunicator) cachePutString(ctx context.Context, property, value string, ttl time.Duration) {
+	key, ok := o.cacheKey(ctx, property)
+	if !ok {
+		return
+	}
+	if err := o.cache.Put(ctx, key, []byte(value), ttl); err != nil {
+		log.Ctx(ctx).Trace().Err(err).Str("property", property).Msg("failed to write property to cache")
+	}
+}
+
+// identifyCacheTTL returns the configured TTL for identify properties
+// (vendor, model, serial number, ...), falling back to
+// cache.DefaultIdentifyTTL if unset.
+func (o *deviceClassCommunicator) identifyCacheTTL() time.Duration {
+	if o.cacheTTLs.IdentifyTTL > 0 {
+		return o.cacheTTLs.IdentifyTTL
+	}
+	return cache.DefaultIdentifyTTL
+}
+
+// ifTableCacheTTL returns the configured TTL for the last-good GetIfTable
+// result, falling back to cache.DefaultIfTableTTL if unset.
+func (o *deviceClassCommunicator) ifTableCacheTTL() time.Duration {
+	if o.cacheTTLs.IfTableTTL > 0 {
+		return o.cacheTTLs.IfTableTTL
+	}
+	return cache.DefaultIfTableTTL
+}
+
+const ifTableCacheProperty = "if_table"
+
+// cacheGetIfTable returns the last successful GetIfTable result, if caching
+// is enabled and a result is still within its TTL.
+func (o *deviceClassCommunicator) cacheGetIfTable(ctx context.Context) ([]device.Interface, bool) {
+	raw, ok := o.cacheGetString(ctx, ifTableCacheProperty)
+	if !ok {
+		return nil, false
+	}
+	var interfaces []device.Interface
+	if err := json.Unmarshal([]byte(raw), &interfaces); err != nil {
+		log.Ctx(ctx).Trace().Err(err).Msg("failed to decode cached ifTable")
+		return nil, false
+	}
+	return interfaces, true
+}
+
+// cachePutIfTable stores the last successful GetIfTable result with the
+// configured (short) TTL, so a transient SNMP timeout on the next poll can
+// be smoothed over instead of surfacing as a readout failure.
+func (o *deviceClassCommunicator) cachePutIfTable(ctx context.Context, interfaces []device.Interface) {
+	if o.cache == nil {
+		return
+	}
+	raw, err := json.Marshal(interfaces)
+	if err != nil {
+		log.Ctx(ctx).Trace().Err(err).Msg("failed to encode ifTable for cache")
+		return
+	}
+	o.cachePutString(ctx, ifTableCacheProperty, string(raw), o.ifTableCacheTTL())
+}
@@ -0,0 +1,60 @@
+package communicator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/inexio/thola/core/communicator/cache"
+	"github.com/inexio/thola/core/network"
+)
+
+func contextWithHostAndCommunity(host, community string) context.Context {
+	return network.NewContextWithDeviceConnection(context.Background(), network.DeviceConnection{
+		Host: host,
+		SNMP: &network.SNMPConnection{Version: "2c", Community: community},
+	})
+}
+
+func TestCacheKey_DisabledWithoutCache(t *testing.T) {
+	o := &deviceClassCommunicator{deviceClass: &deviceClass{name: "test-class"}}
+	if _, ok := o.cacheKey(contextWithHostAndCommunity("host1", "public"), "vendor"); ok {
+		t.Fatal("cacheKey should report disabled when o.cache is nil")
+	}
+}
+
+func TestCacheKey_DistinguishesHostCredentialsAndClass(t *testing.T) {
+	o := &deviceClassCommunicator{deviceClass: &deviceClass{name: "class-a"}, cache: cache.Noop{}}
+
+	key1, ok := o.cacheKey(contextWithHostAndCommunity("host1", "public"), "vendor")
+	if !ok {
+		t.Fatal("expected cacheKey to succeed with a device connection in context")
+	}
+
+	cases := []struct {
+		name string
+		ctx  context.Context
+		com  *deviceClassCommunicator
+	}{
+		{"different host", contextWithHostAndCommunity("host2", "public"), o},
+		{"different community", contextWithHostAndCommunity("host1", "private"), o},
+		{"different device class", contextWithHostAndCommunity("host1", "public"), &deviceClassCommunicator{deviceClass: &deviceClass{name: "class-b"}, cache: cache.Noop{}}},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			key2, ok := tt.com.cacheKey(tt.ctx, "vendor")
+			if !ok {
+				t.Fatal("expected cacheKey to succeed")
+			}
+			if key1 == key2 {
+				t.Fatalf("expected distinct cache keys, both were %q", key1)
+			}
+		})
+	}
+}
+
+func TestCacheKey_NoDeviceConnectionInContext(t *testing.T) {
+	o := &deviceClassCommunicator{deviceClass: &deviceClass{name: "test-class"}, cache: cache.Noop{}}
+	if _, ok := o.cacheKey(context.Background(), "vendor"); ok {
+		t.Fatal("cacheKey should report disabled without a device connection in context")
+	}
+}
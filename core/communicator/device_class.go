@@ -0,0 +1,162 @@
+package communicator
+
+import (
+	"context"
+
+	"github.com/inexio/thola/core/device"
+	"github.com/inexio/thola/core/network"
+	"github.com/inexio/thola/core/value"
+)
+
+// Communicator is the full set of per-device readouts a deviceClass can be
+// asked for. deviceClassCommunicator implements it generically from a
+// deviceClass's declarative config; vendor-specific communicators embed
+// baseCommunicator and override individual methods.
+type Communicator interface {
+	GetVendor(ctx context.Context) (string, error)
+	GetModel(ctx context.Context) (string, error)
+	GetModelSeries(ctx context.Context) (string, error)
+	GetSerialNumber(ctx context.Context) (string, error)
+	GetOSVersion(ctx context.Context) (string, error)
+	GetIfTable(ctx context.Context) ([]device.Interface, error)
+	GetInterfaces(ctx context.Context) ([]device.Interface, error)
+	GetCountInterfaces(ctx context.Context) (int, error)
+}
+
+// baseCommunicator is embedded by every concrete communicator in a device
+// class's inheritance chain. head always points at the outermost
+// communicator for that chain, so a base implementation that calls back into
+// e.g. GetInterfaces picks up overrides from a more specific device class
+// instead of re-running its own (possibly generic) logic.
+type baseCommunicator struct {
+	head interface {
+		GetIfTable(ctx context.Context) ([]device.Interface, error)
+		GetInterfaces(ctx context.Context) ([]device.Interface, error)
+	}
+}
+
+// deviceClass is the parsed, YAML-defined description of how to identify a
+// vendor/model and which components it exposes. deviceClassCommunicator
+// embeds *deviceClass to turn this declarative description into the
+// Communicator interface.
+type deviceClass struct {
+	name       string
+	identify   deviceClassIdentify
+	components deviceClassComponents
+}
+
+// deviceClassIdentify declares how to read the handful of static identify
+// properties (vendor, model, ...) for a device class.
+type deviceClassIdentify struct {
+	properties deviceClassIdentifyProperties
+}
+
+// deviceClassIdentifyProperties holds one propertyReader per identify
+// property; a nil field means the device class doesn't declare that
+// property.
+type deviceClassIdentifyProperties struct {
+	vendor       propertyReader
+	model        propertyReader
+	modelSeries  propertyReader
+	serialNumber propertyReader
+	osVersion    propertyReader
+}
+
+// deviceClassComponents declares which optional component subsystems
+// (interfaces, UPS, generalized sensors, ...) a device class models. Every
+// field is nil unless the YAML definition declares that component, and
+// every GetX communicator method checks its field before doing any work.
+type deviceClassComponents struct {
+	interfaces *deviceClassComponentsInterfaces `yaml:"interfaces"`
+	ups        *deviceClassComponentsUPS        `yaml:"ups"`
+
+	// sensors declares the generalized power/temperature/fan/chassis-health
+	// sensors this device class exposes (see sensor.go). It is the piece
+	// GetPowerSensors/GetTemperatureSensors/GetFanSensors/GetChassisHealth
+	// read, and the piece getUPSPowerSensorValue/getUPSTemperatureSensorValue
+	// prefer over the legacy per-metric UPS properties when a sensor of the
+	// same name is declared.
+	sensors *deviceClassSensors `yaml:"sensors"`
+}
+
+// deviceClassComponentsInterfaces declares how to read out the interface
+// table: the OIDs backing the base ifTable columns, any per-type overlay
+// OIDs, and (optionally) a single OID that returns the interface count
+// directly instead of requiring a full walk.
+type deviceClassComponentsInterfaces struct {
+	IfTable deviceClassInterfaceOIDs      `yaml:"if_table"`
+	Types   []deviceClassInterfaceTypeDef `yaml:"types"`
+	Count   string                        `yaml:"count_oid"`
+}
+
+// deviceClassInterfaceTypeDef declares additional OIDs to overlay onto
+// interfaces of a particular type, keyed by ifIndex just like IfTable.
+type deviceClassInterfaceTypeDef struct {
+	Values deviceClassInterfaceOIDs `yaml:"values"`
+}
+
+// deviceClassComponentsUPS declares the legacy, one-OID-per-metric UPS
+// properties. Any of them may be nil, in which case the corresponding
+// GetUPSComponent* method falls back to tholaerr.ErrPropertyNotModeled
+// unless the same metric is also declared under components.sensors.
+// alarmLowVoltageDisconnect and mainsVoltageApplied have no sensor
+// equivalent and are only read from these fields.
+type deviceClassComponentsUPS struct {
+	alarmLowVoltageDisconnect propertyReader
+	batteryAmperage           propertyReader
+	batteryCapacity           propertyReader
+	batteryCurrent            propertyReader
+	batteryRemainingTime      propertyReader
+	batteryTemperature        propertyReader
+	batteryVoltage            propertyReader
+	currentLoad               propertyReader
+	mainsVoltageApplied       propertyReader
+	rectifierCurrent          propertyReader
+	systemVoltage             propertyReader
+}
+
+// deviceClassOID declares how a single OID-backed property is read out and
+// normalized. It backs every interface column, every per-type overlay
+// value, and every declared sensor (see sensor.go).
+type deviceClassOID struct {
+	OID                  value.OID
+	SNMPGetConfiguration network.SNMPGetConfiguration
+	operators            propertyOperators
+
+	// optional marks this OID as skip-on-error instead of fail-the-whole-
+	// readout for getValuesBySNMPWalk's bounded worker pool: if the walk or
+	// normalization for this OID fails, it is logged and skipped rather
+	// than aborting every other OID in flight.
+	optional bool `yaml:"optional"`
+	// maxRepetitions overrides the bulk-walk page size used for this OID's
+	// SNMP walk; zero keeps the SNMP client's own default.
+	maxRepetitions int `yaml:"max_repetitions"`
+}
+
+// deviceClassInterfaceOIDs maps an output field name to its OID definition,
+// used for both the ifTable walk and per-type overlay values.
+type deviceClassInterfaceOIDs map[string]deviceClassOID
+
+// propertyOperators is the normalization chain (unit conversion, regex
+// extraction, ...) applied to a raw SNMP value before it is stored.
+type propertyOperators []propertyOperator
+
+// propertyOperator is a single normalization step in a propertyOperators
+// chain.
+type propertyOperator interface {
+	apply(ctx context.Context, raw value.Value) (value.Value, error)
+}
+
+// apply runs raw through every operator in the chain in order, short
+// circuiting on the first error.
+func (ops propertyOperators) apply(ctx context.Context, raw value.Value) (value.Value, error) {
+	result := raw
+	for _, op := range ops {
+		var err error
+		result, err = op.apply(ctx, result)
+		if err != nil {
+			return "", err
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,54 @@
+package communicator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/inexio/thola/core/communicator/cache"
+	"github.com/rs/zerolog/log"
+)
+
+// deviceClassCacheKey builds the cache key a device class is memoized under
+// for a given target, in the same (host, credentials) style as
+// deviceClassCommunicator.cacheKey - but unlike cacheKey this isn't a method
+// on deviceClassCommunicator, since there's no instance for one yet: the
+// whole point is to look the class up before deciding whether detection
+// needs to run at all.
+func deviceClassCacheKey(host, credentialsHash string) string {
+	return fmt.Sprintf("thola:%s:%s:device_class", host, credentialsHash)
+}
+
+// CachedDeviceClassName returns the name of the device class last resolved
+// for (host, credentialsHash), if c has one cached and it hasn't expired. A
+// detector should call this before running full detection against every
+// known device class, and only fall back to full detection on a miss.
+func CachedDeviceClassName(ctx context.Context, c cache.Cache, host, credentialsHash string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	val, found, err := c.Get(ctx, deviceClassCacheKey(host, credentialsHash))
+	if err != nil || !found {
+		return "", false
+	}
+	return string(val), true
+}
+
+// CacheDeviceClassName caches name as the resolved device class for (host,
+// credentialsHash) for ttl, falling back to cache.DefaultDeviceClassTTL if
+// ttl is unset. NewDeviceClassCommunicator calls this whenever it builds a
+// Communicator for a freshly resolved class, so a future detector consulting
+// CachedDeviceClassName doesn't need to re-probe every known class on its
+// next poll. A nil or failing cache is not an error - caching is an
+// optimization, never a source of truth.
+func CacheDeviceClassName(ctx context.Context, c cache.Cache, host, credentialsHash, name string, ttl time.Duration) {
+	if c == nil {
+		return
+	}
+	if ttl <= 0 {
+		ttl = cache.DefaultDeviceClassTTL
+	}
+	if err := c.Put(ctx, deviceClassCacheKey(host, credentialsHash), []byte(name), ttl); err != nil {
+		log.Ctx(ctx).Trace().Err(err).Str("host", host).Msg("failed to cache resolved device class")
+	}
+}
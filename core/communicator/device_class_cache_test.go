@@ -0,0 +1,73 @@
+package communicator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/inexio/thola/core/communicator/cache"
+)
+
+func TestCachedDeviceClassName_Miss(t *testing.T) {
+	if _, ok := CachedDeviceClassName(context.Background(), cache.Noop{}, "host1", "hash1"); ok {
+		t.Fatal("expected a miss against an empty cache")
+	}
+	if _, ok := CachedDeviceClassName(context.Background(), nil, "host1", "hash1"); ok {
+		t.Fatal("expected a miss with a nil cache")
+	}
+}
+
+// fakeCache is a minimal in-memory cache.Cache for exercising
+// CacheDeviceClassName/CachedDeviceClassName without a real backend.
+type fakeCache struct {
+	values map[string][]byte
+}
+
+func newFakeCache() *fakeCache { return &fakeCache{values: map[string][]byte{}} }
+
+func (c *fakeCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	val, ok := c.values[key]
+	return val, ok, nil
+}
+
+func (c *fakeCache) Put(_ context.Context, key string, value []byte, _ time.Duration) error {
+	c.values[key] = value
+	return nil
+}
+
+func (c *fakeCache) Watch(ctx context.Context, _ string) (<-chan []byte, error) {
+	ch := make(chan []byte)
+	go func() {
+		<-ctx.Done()
+		close(ch)
+	}()
+	return ch, nil
+}
+
+func TestCacheDeviceClassName_RoundTrip(t *testing.T) {
+	c := newFakeCache()
+	ctx := context.Background()
+
+	CacheDeviceClassName(ctx, c, "host1", "hash1", "vendor-x", time.Hour)
+
+	name, ok := CachedDeviceClassName(ctx, c, "host1", "hash1")
+	if !ok || name != "vendor-x" {
+		t.Fatalf("CachedDeviceClassName = (%q, %v), want (\"vendor-x\", true)", name, ok)
+	}
+
+	if _, ok := CachedDeviceClassName(ctx, c, "host2", "hash1"); ok {
+		t.Fatal("expected a different host to miss")
+	}
+	if _, ok := CachedDeviceClassName(ctx, c, "host1", "hash2"); ok {
+		t.Fatal("expected a different credentials hash to miss")
+	}
+}
+
+func TestNewDeviceClassCommunicator_DisabledCacheDoesNotError(t *testing.T) {
+	ctx := context.Background()
+	dc := &deviceClass{name: "resolved-class"}
+
+	if _, err := NewDeviceClassCommunicator(ctx, dc, cache.BackendConfig{Backend: "none"}, "host1", "hash1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
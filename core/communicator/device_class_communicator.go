@@ -3,6 +3,7 @@ package communicator
 import (
 	"context"
 	"fmt"
+	"github.com/inexio/thola/core/communicator/cache"
 	"github.com/inexio/thola/core/device"
 	"github.com/inexio/thola/core/network"
 	"github.com/inexio/thola/core/tholaerr"
@@ -12,91 +13,100 @@ import (
 	"github.com/rs/zerolog/log"
 	"sort"
 	"strings"
+	"sync"
 )
 
 type deviceClassCommunicator struct {
 	baseCommunicator
 	*deviceClass
+
+	// cache optionally memoizes detection/readout results across polls of
+	// the same device (see cached_properties.go). NewDeviceClassCommunicator
+	// sets it to cache.Noop{} when its cache.BackendConfig selects no
+	// backend; a communicator built without it leaves cache nil, which is
+	// treated the same way, so behavior is unchanged when caching is
+	// disabled.
+	cache     cache.Cache
+	cacheTTLs cache.BackendConfig
 }
 
-func (o *deviceClassCommunicator) GetVendor(ctx context.Context) (string, error) {
-	if o.identify.properties.vendor == nil {
-		log.Ctx(ctx).Trace().Str("property", "vendor").Msg("no detection information available")
-		return "", tholaerr.NewNotImplementedError("no detection information available")
-	}
-	logger := log.Ctx(ctx).With().Str("property", "vendor").Logger()
-	ctx = logger.WithContext(ctx)
-	vendor, err := o.identify.properties.vendor.getProperty(ctx)
+// NewDeviceClassCommunicator builds the Communicator for dc, the device
+// class resolved for (host, credentialsHash) - typically by a detector
+// trying CachedDeviceClassName first and falling back to full detection.
+// cacheConfig selects the optional cache backend results are memoized
+// against (see cached_properties.go); its zero value disables caching,
+// equivalent to passing cache.BackendConfig{Backend: "none"}.
+//
+// NewDeviceClassCommunicator also writes dc's name back to the cache under
+// cacheConfig.DeviceClassTTL, so the next detection attempt for the same
+// target can skip straight to this device class via CachedDeviceClassName
+// instead of re-probing every known class.
+func NewDeviceClassCommunicator(ctx context.Context, dc *deviceClass, cacheConfig cache.BackendConfig, host, credentialsHash string) (Communicator, error) {
+	c, err := cache.NewCache(ctx, cacheConfig)
 	if err != nil {
-		log.Ctx(ctx).Trace().Err(err).Msg("failed to get property")
-		return "", errors.Wrap(err, "failed to get vendor")
+		return nil, errors.Wrap(err, "failed to initialize cache backend")
 	}
 
-	return strings.TrimSpace(vendor.String()), nil
+	o := &deviceClassCommunicator{
+		deviceClass: dc,
+		cache:       c,
+		cacheTTLs:   cacheConfig,
+	}
+	o.baseCommunicator.head = o
+
+	CacheDeviceClassName(ctx, c, host, credentialsHash, dc.name, cacheConfig.DeviceClassTTL)
+
+	return o, nil
 }
 
-func (o *deviceClassCommunicator) GetModel(ctx context.Context) (string, error) {
-	if o.identify.properties.model == nil {
-		log.Ctx(ctx).Trace().Str("property", "model").Msg("no detection information available")
-		return "", tholaerr.NewNotImplementedError("no detection information available")
-	}
-	logger := log.Ctx(ctx).With().Str("property", "model").Logger()
-	ctx = logger.WithContext(ctx)
-	model, err := o.identify.properties.model.getProperty(ctx)
-	if err != nil {
-		log.Ctx(ctx).Trace().Err(err).Msg("failed to get property")
-		return "", errors.Wrap(err, "failed to get model")
-	}
+func (o *deviceClassCommunicator) GetVendor(ctx context.Context) (string, error) {
+	return o.getIdentifyProperty(ctx, "vendor", o.identify.properties.vendor)
+}
 
-	return strings.TrimSpace(model.String()), nil
+func (o *deviceClassCommunicator) GetModel(ctx context.Context) (string, error) {
+	return o.getIdentifyProperty(ctx, "model", o.identify.properties.model)
 }
 
 func (o *deviceClassCommunicator) GetModelSeries(ctx context.Context) (string, error) {
-	if o.identify.properties.modelSeries == nil {
-		log.Ctx(ctx).Trace().Str("property", "model_series").Msg("no detection information available")
-		return "", tholaerr.NewNotImplementedError("no detection information available")
-	}
-	logger := log.Ctx(ctx).With().Str("property", "model_series").Logger()
-	ctx = logger.WithContext(ctx)
-	modelSeries, err := o.identify.properties.modelSeries.getProperty(ctx)
-	if err != nil {
-		log.Ctx(ctx).Trace().Err(err).Msg("failed to get property")
-		return "", errors.Wrap(err, "failed to get model_series")
-	}
-
-	return strings.TrimSpace(modelSeries.String()), nil
+	return o.getIdentifyProperty(ctx, "model_series", o.identify.properties.modelSeries)
 }
 
 func (o *deviceClassCommunicator) GetSerialNumber(ctx context.Context) (string, error) {
-	if o.identify.properties.serialNumber == nil {
-		log.Ctx(ctx).Trace().Str("property", "serial_number").Msg("no detection information available")
-		return "", tholaerr.NewNotImplementedError("no detection information available")
-	}
-	logger := log.Ctx(ctx).With().Str("property", "serial_number").Logger()
-	ctx = logger.WithContext(ctx)
-	serialNumber, err := o.identify.properties.serialNumber.getProperty(ctx)
-	if err != nil {
-		log.Ctx(ctx).Trace().Err(err).Msg("failed to get property")
-		return "", errors.Wrap(err, "failed to get serial_number")
-	}
-
-	return strings.TrimSpace(serialNumber.String()), nil
+	return o.getIdentifyProperty(ctx, "serial_number", o.identify.properties.serialNumber)
 }
 
 func (o *deviceClassCommunicator) GetOSVersion(ctx context.Context) (string, error) {
-	if o.identify.properties.osVersion == nil {
-		log.Ctx(ctx).Trace().Str("property", "osVersion").Msg("no detection information available")
-		return "", tholaerr.NewNotImplementedError("no detection information available")
+	return o.getIdentifyProperty(ctx, "osVersion", o.identify.properties.osVersion)
+}
+
+// getIdentifyProperty reads a single identify property (vendor, model, ...),
+// returning a typed tholaerr.ErrPropertyNotModeled if the device class
+// doesn't declare it, or a typed tholaerr.ErrDetection wrapping the
+// underlying cause if the declared property fails to read. Callers (REST
+// handlers, CLI, check plugins) can tell these apart with
+// tholaerr.IsPropertyNotModeledError / tholaerr.IsDetectionError instead of
+// matching on error string prefixes.
+func (o *deviceClassCommunicator) getIdentifyProperty(ctx context.Context, name string, prop propertyReader) (string, error) {
+	if cached, ok := o.cacheGetString(ctx, name); ok {
+		log.Ctx(ctx).Trace().Str("property", name).Msg("identify property served from cache")
+		return cached, nil
+	}
+
+	if prop == nil {
+		log.Ctx(ctx).Trace().Str("property", name).Msg("no detection information available")
+		return "", tholaerr.NewPropertyNotModeledError(map[string]interface{}{"property": name})
 	}
-	logger := log.Ctx(ctx).With().Str("property", "osVersion").Logger()
+	logger := log.Ctx(ctx).With().Str("property", name).Logger()
 	ctx = logger.WithContext(ctx)
-	version, err := o.identify.properties.osVersion.getProperty(ctx)
+	res, err := prop.getProperty(ctx)
 	if err != nil {
 		log.Ctx(ctx).Trace().Err(err).Msg("failed to get property")
-		return "", errors.Wrap(err, "failed to get osVersion")
+		return "", tholaerr.NewDetectionError(map[string]interface{}{"property": name}, err)
 	}
 
-	return strings.TrimSpace(version.String()), nil
+	result := strings.TrimSpace(res.String())
+	o.cachePutString(ctx, name, result, o.identifyCacheTTL())
+	return result, nil
 }
 
 func (o *deviceClassCommunicator) GetInterfaces(ctx context.Context) ([]device.Interface, error) {
@@ -139,6 +149,10 @@ func (o *deviceClassCommunicator) GetIfTable(ctx context.Context) ([]device.Inte
 
 	networkInterfacesRaw, err := o.getValuesBySNMPWalk(ctx, o.components.interfaces.IfTable)
 	if err != nil {
+		if cached, ok := o.cacheGetIfTable(ctx); ok {
+			log.Ctx(ctx).Trace().Err(err).Msg("ifTable walk failed, serving last cached result")
+			return cached, nil
+		}
 		return nil, err
 	}
 
@@ -158,6 +172,8 @@ func (o *deviceClassCommunicator) GetIfTable(ctx context.Context) ([]device.Inte
 		return *networkInterfaces[i].IfIndex < *networkInterfaces[j].IfIndex
 	})
 
+	o.cachePutIfTable(ctx, networkInterfaces)
+
 	return networkInterfaces, nil
 }
 
@@ -175,7 +191,7 @@ func (o *deviceClassCommunicator) GetCountInterfaces(ctx context.Context) (int,
 
 	oid := o.components.interfaces.Count
 
-	snmpResponse, err := con.SNMP.SnmpClient.SNMPGet(ctx, oid)
+	snmpResponse, err := con.SNMP.SNMPGet(ctx, oid)
 
 	if err == nil {
 		response, err := snmpResponse[0].GetValue()
@@ -185,7 +201,7 @@ func (o *deviceClassCommunicator) GetCountInterfaces(ctx context.Context) (int,
 			}
 			err := fmt.Errorf("could not parse response to int, response has type %T", response)
 			log.Ctx(ctx).Trace().Err(err).Msgf("could not parse response to int, response has type %T", response)
-			return 0, err
+			return 0, tholaerr.NewValueCoercionError(map[string]interface{}{"property": "count_interfaces", "oid": oid}, err)
 		}
 		log.Ctx(ctx).Trace().Err(err).Msg("response is empty")
 		return 0, errors.Wrap(err, "response is empty")
@@ -204,253 +220,313 @@ func (o *deviceClassCommunicator) GetCountInterfaces(ctx context.Context) (int,
 func (o *deviceClassCommunicator) GetUPSComponentAlarmLowVoltageDisconnect(ctx context.Context) (int, error) {
 	if o.components.ups == nil || o.components.ups.alarmLowVoltageDisconnect == nil {
 		log.Ctx(ctx).Trace().Str("property", "UPSComponentAlarmLowVoltageDisconnect").Msg("no detection information available")
-		return 0, tholaerr.NewNotImplementedError("no detection information available")
+		return 0, tholaerr.NewPropertyNotModeledError(map[string]interface{}{"property": "UPSComponentAlarmLowVoltageDisconnect"})
 	}
 	logger := log.Ctx(ctx).With().Str("property", "UPSComponentAlarmAlarmLowVoltageDisconnect").Logger()
 	ctx = logger.WithContext(ctx)
 	res, err := o.components.ups.alarmLowVoltageDisconnect.getProperty(ctx)
 	if err != nil {
 		log.Ctx(ctx).Trace().Err(err).Msg("failed to get property")
-		return 0, errors.Wrap(err, "failed to get UPSComponentAlarmAlarmLowVoltageDisconnect")
+		return 0, tholaerr.NewDetectionError(map[string]interface{}{"property": "UPSComponentAlarmLowVoltageDisconnect"}, err)
 	}
 	r, err := res.Int()
 	if err != nil {
-		return 0, errors.Wrapf(err, "failed to convert value '%s' to int", res.String())
+		return 0, tholaerr.NewValueCoercionError(map[string]interface{}{"property": "UPSComponentAlarmLowVoltageDisconnect", "raw_value": res.String()}, err)
 	}
 	return r, nil
 }
 
 func (o *deviceClassCommunicator) GetUPSComponentBatteryAmperage(ctx context.Context) (float64, error) {
-	if o.components.ups == nil || o.components.ups.batteryAmperage == nil {
+	if o.components.ups == nil {
 		log.Ctx(ctx).Trace().Str("property", "UPSComponentBatteryAmperage").Msg("no detection information available")
-		return 0, tholaerr.NewNotImplementedError("no detection information available")
-	}
-	logger := log.Ctx(ctx).With().Str("property", "UPSComponentBatteryAmperage").Logger()
-	ctx = logger.WithContext(ctx)
-	res, err := o.components.ups.batteryAmperage.getProperty(ctx)
-	if err != nil {
-		log.Ctx(ctx).Trace().Err(err).Msg("failed to get property")
-		return 0, errors.Wrap(err, "failed to get UPSComponentBatteryAmperage")
+		return 0, tholaerr.NewPropertyNotModeledError(map[string]interface{}{"property": "UPSComponentBatteryAmperage"})
 	}
-	result, err := res.Float64()
-	if err != nil {
-		return 0, errors.Wrapf(err, "failed to convert result '%v' to float64", res)
-	}
-	return result, nil
+	return o.getUPSPowerSensorValue(ctx, "battery_amperage", o.components.ups.batteryAmperage)
 }
 
 func (o *deviceClassCommunicator) GetUPSComponentBatteryCapacity(ctx context.Context) (float64, error) {
-	if o.components.ups == nil || o.components.ups.batteryCapacity == nil {
+	if o.components.ups == nil {
 		log.Ctx(ctx).Trace().Str("property", "UPSComponentBatteryCapacity").Msg("no detection information available")
-		return 0, tholaerr.NewNotImplementedError("no detection information available")
-	}
-	logger := log.Ctx(ctx).With().Str("property", "UPSComponentBatteryCapacity").Logger()
-	ctx = logger.WithContext(ctx)
-	res, err := o.components.ups.batteryCapacity.getProperty(ctx)
-	if err != nil {
-		log.Ctx(ctx).Trace().Err(err).Msg("failed to get property")
-		return 0, errors.Wrap(err, "failed to get UPSComponentBatteryCapacity")
-	}
-	result, err := res.Float64()
-	if err != nil {
-		return 0, errors.Wrapf(err, "failed to convert result '%v' to float64", res)
+		return 0, tholaerr.NewPropertyNotModeledError(map[string]interface{}{"property": "UPSComponentBatteryCapacity"})
 	}
-	return result, nil
+	return o.getUPSPowerSensorValue(ctx, "battery_capacity", o.components.ups.batteryCapacity)
 }
 
 func (o *deviceClassCommunicator) GetUPSComponentBatteryCurrent(ctx context.Context) (float64, error) {
-	if o.components.ups == nil || o.components.ups.batteryCurrent == nil {
+	if o.components.ups == nil {
 		log.Ctx(ctx).Trace().Str("property", "UPSComponentBatteryCurrent").Msg("no detection information available")
-		return 0, tholaerr.NewNotImplementedError("no detection information available")
-	}
-	logger := log.Ctx(ctx).With().Str("property", "UPSComponentBatteryCurrent").Logger()
-	ctx = logger.WithContext(ctx)
-	res, err := o.components.ups.batteryCurrent.getProperty(ctx)
-	if err != nil {
-		log.Ctx(ctx).Trace().Err(err).Msg("failed to get property")
-		return 0, errors.Wrap(err, "failed to get UPSComponentBatteryCurrent")
+		return 0, tholaerr.NewPropertyNotModeledError(map[string]interface{}{"property": "UPSComponentBatteryCurrent"})
 	}
-	result, err := res.Float64()
-	if err != nil {
-		return 0, errors.Wrapf(err, "failed to convert result '%v' to float64", res)
-	}
-	return result, nil
+	return o.getUPSPowerSensorValue(ctx, "battery_current", o.components.ups.batteryCurrent)
 }
 
 func (o *deviceClassCommunicator) GetUPSComponentBatteryRemainingTime(ctx context.Context) (float64, error) {
-	if o.components.ups == nil || o.components.ups.batteryRemainingTime == nil {
+	if o.components.ups == nil {
 		log.Ctx(ctx).Trace().Str("property", "UPSComponentBatteryRemainingTime").Msg("no detection information available")
-		return 0, tholaerr.NewNotImplementedError("no detection information available")
+		return 0, tholaerr.NewPropertyNotModeledError(map[string]interface{}{"property": "UPSComponentBatteryRemainingTime"})
 	}
-	logger := log.Ctx(ctx).With().Str("property", "UPSComponentBatteryRemainingTime").Logger()
-	ctx = logger.WithContext(ctx)
-	res, err := o.components.ups.batteryRemainingTime.getProperty(ctx)
-	if err != nil {
-		log.Ctx(ctx).Trace().Err(err).Msg("failed to get property")
-		return 0, errors.Wrap(err, "failed to get UPSComponentBatteryRemainingTime")
-	}
-	result, err := res.Float64()
-	if err != nil {
-		return 0, errors.Wrapf(err, "failed to convert result '%v' to float64", res)
-	}
-	return result, nil
+	return o.getUPSPowerSensorValue(ctx, "battery_remaining_time", o.components.ups.batteryRemainingTime)
 }
 
 func (o *deviceClassCommunicator) GetUPSComponentBatteryTemperature(ctx context.Context) (float64, error) {
-	if o.components.ups == nil || o.components.ups.batteryTemperature == nil {
+	if o.components.ups == nil {
 		log.Ctx(ctx).Trace().Str("property", "UPSComponentBatteryTemperature").Msg("no detection information available")
-		return 0, tholaerr.NewNotImplementedError("no detection information available")
-	}
-	logger := log.Ctx(ctx).With().Str("property", "UPSComponentBatteryTemperature").Logger()
-	ctx = logger.WithContext(ctx)
-	res, err := o.components.ups.batteryTemperature.getProperty(ctx)
-	if err != nil {
-		log.Ctx(ctx).Trace().Err(err).Msg("failed to get property")
-		return 0, errors.Wrap(err, "failed to get UPSComponentBatteryTemperature")
-	}
-	result, err := res.Float64()
-	if err != nil {
-		return 0, errors.Wrapf(err, "failed to convert result '%v' to float64", res)
+		return 0, tholaerr.NewPropertyNotModeledError(map[string]interface{}{"property": "UPSComponentBatteryTemperature"})
 	}
-	return result, nil
+	return o.getUPSTemperatureSensorValue(ctx, "battery_temperature", o.components.ups.batteryTemperature)
 }
 
 func (o *deviceClassCommunicator) GetUPSComponentBatteryVoltage(ctx context.Context) (float64, error) {
-	if o.components.ups == nil || o.components.ups.batteryVoltage == nil {
+	if o.components.ups == nil {
 		log.Ctx(ctx).Trace().Str("property", "UPSComponentBatteryVoltage").Msg("no detection information available")
-		return 0, tholaerr.NewNotImplementedError("no detection information available")
-	}
-	logger := log.Ctx(ctx).With().Str("property", "UPSComponentBatteryVoltage").Logger()
-	ctx = logger.WithContext(ctx)
-	res, err := o.components.ups.batteryVoltage.getProperty(ctx)
-	if err != nil {
-		log.Ctx(ctx).Trace().Err(err).Msg("failed to get property")
-		return 0, errors.Wrap(err, "failed to get UPSComponentBatteryVoltage")
+		return 0, tholaerr.NewPropertyNotModeledError(map[string]interface{}{"property": "UPSComponentBatteryVoltage"})
 	}
-	result, err := res.Float64()
-	if err != nil {
-		return 0, errors.Wrapf(err, "failed to convert result '%v' to float64", res)
-	}
-	return result, nil
+	return o.getUPSPowerSensorValue(ctx, "battery_voltage", o.components.ups.batteryVoltage)
 }
 
 func (o *deviceClassCommunicator) GetUPSComponentCurrentLoad(ctx context.Context) (float64, error) {
-	if o.components.ups == nil || o.components.ups.currentLoad == nil {
+	if o.components.ups == nil {
 		log.Ctx(ctx).Trace().Str("property", "UPSComponentCurrentLoad").Msg("no detection information available")
-		return 0, tholaerr.NewNotImplementedError("no detection information available")
-	}
-	logger := log.Ctx(ctx).With().Str("property", "UPSComponentCurrentLoad").Logger()
-	ctx = logger.WithContext(ctx)
-	res, err := o.components.ups.currentLoad.getProperty(ctx)
-	if err != nil {
-		log.Ctx(ctx).Trace().Err(err).Msg("failed to get property")
-		return 0, errors.Wrap(err, "failed to get UPSComponentCurrentLoad")
-	}
-	result, err := res.Float64()
-	if err != nil {
-		return 0, errors.Wrapf(err, "failed to convert result '%v' to float64", res)
+		return 0, tholaerr.NewPropertyNotModeledError(map[string]interface{}{"property": "UPSComponentCurrentLoad"})
 	}
-	return result, nil
+	return o.getUPSPowerSensorValue(ctx, "current_load", o.components.ups.currentLoad)
 }
 
 func (o *deviceClassCommunicator) GetUPSComponentMainsVoltageApplied(ctx context.Context) (bool, error) {
 	if o.components.ups == nil || o.components.ups.mainsVoltageApplied == nil {
 		log.Ctx(ctx).Trace().Str("property", "UPSComponentMainsVoltageApplied").Msg("no detection information available")
-		return false, tholaerr.NewNotImplementedError("no detection information available")
+		return false, tholaerr.NewPropertyNotModeledError(map[string]interface{}{"property": "UPSComponentMainsVoltageApplied"})
 	}
 	logger := log.Ctx(ctx).With().Str("property", "UPSComponentMainsVoltageApplied").Logger()
 	ctx = logger.WithContext(ctx)
 	res, err := o.components.ups.mainsVoltageApplied.getProperty(ctx)
 	if err != nil {
 		log.Ctx(ctx).Trace().Err(err).Msg("failed to get property")
-		return false, errors.Wrap(err, "failed to get UPSComponentMainsVoltageApplied")
+		return false, tholaerr.NewDetectionError(map[string]interface{}{"property": "UPSComponentMainsVoltageApplied"}, err)
 	}
 	r, err := res.Bool()
 	if err != nil {
-		return false, errors.Wrapf(err, "failed to parse value '%s' to bool", res.String())
+		return false, tholaerr.NewValueCoercionError(map[string]interface{}{"property": "UPSComponentMainsVoltageApplied", "raw_value": res.String()}, err)
 	}
 	return r, nil
 }
 
 func (o *deviceClassCommunicator) GetUPSComponentRectifierCurrent(ctx context.Context) (float64, error) {
-	if o.components.ups == nil || o.components.ups.rectifierCurrent == nil {
+	if o.components.ups == nil {
 		log.Ctx(ctx).Trace().Str("property", "UPSComponentRectifierCurrent").Msg("no detection information available")
-		return 0, tholaerr.NewNotImplementedError("no detection information available")
-	}
-	logger := log.Ctx(ctx).With().Str("property", "UPSComponentRectifierCurrent").Logger()
-	ctx = logger.WithContext(ctx)
-	res, err := o.components.ups.rectifierCurrent.getProperty(ctx)
-	if err != nil {
-		log.Ctx(ctx).Trace().Err(err).Msg("failed to get property")
-		return 0, errors.Wrap(err, "failed to get UPSComponentRectifierCurrent")
+		return 0, tholaerr.NewPropertyNotModeledError(map[string]interface{}{"property": "UPSComponentRectifierCurrent"})
 	}
-	result, err := res.Float64()
-	if err != nil {
-		return 0, errors.Wrapf(err, "failed to convert result '%v' to float64", res)
-	}
-	return result, nil
+	return o.getUPSPowerSensorValue(ctx, "rectifier_current", o.components.ups.rectifierCurrent)
 }
 
 func (o *deviceClassCommunicator) GetUPSComponentSystemVoltage(ctx context.Context) (float64, error) {
-	if o.components.ups == nil || o.components.ups.systemVoltage == nil {
+	if o.components.ups == nil {
 		log.Ctx(ctx).Trace().Str("property", "UPSComponentSystemVoltage").Msg("no detection information available")
-		return 0, tholaerr.NewNotImplementedError("no detection information available")
+		return 0, tholaerr.NewPropertyNotModeledError(map[string]interface{}{"property": "UPSComponentSystemVoltage"})
+	}
+	return o.getUPSPowerSensorValue(ctx, "system_voltage", o.components.ups.systemVoltage)
+}
+
+// propertyReader is implemented by every deviceClass property accessor
+// (identify properties, legacy per-metric UPS readers, ...). It lets
+// getIdentifyProperty and the UPS sensor wrappers share one readout path.
+type propertyReader interface {
+	getProperty(ctx context.Context) (value.Value, error)
+}
+
+// getUPSPowerSensorValue is the thin wrapper the GetUPSComponent* power
+// metrics are built on: it prefers a same-named reading from the
+// generalized power sensor subsystem (see sensor.go) and only falls back to
+// the legacy per-metric property if the device class doesn't declare that
+// sensor at all. This lets existing UPS device classes keep working
+// unchanged. The GetUPSComponent* methods only reach this function when the
+// device class declares a ups component; without one they return
+// tholaerr.ErrPropertyNotModeled and declared sensors are not consulted.
+//
+// Once a sensor is declared, its errors are authoritative: a failed
+// GetPowerSensors call or a reading missing from its result is returned as
+// an error rather than silently falling back to the legacy reader, so a
+// transient SNMP failure on a sensor-modeled device surfaces as that
+// failure instead of being misreported as "property not modeled".
+func (o *deviceClassCommunicator) getUPSPowerSensorValue(ctx context.Context, sensorName string, legacy propertyReader) (float64, error) {
+	if o.components.sensors != nil && o.components.sensors.power != nil {
+		if _, ok := o.components.sensors.power[sensorName]; ok {
+			sensors, err := o.GetPowerSensors(ctx)
+			if err != nil {
+				return 0, err
+			}
+			for _, s := range sensors {
+				if s.Name == sensorName {
+					return s.Value, nil
+				}
+			}
+			return 0, tholaerr.NewPropertyNotModeledError(map[string]interface{}{"property": sensorName, "reason": "sensor declared but missing from readout"})
+		}
+	}
+	return getLegacyUPSFloatProperty(ctx, legacy, sensorName)
+}
+
+// getUPSTemperatureSensorValue is the temperature-sensor equivalent of
+// getUPSPowerSensorValue; see its docs for the sensor-declared-but-failed
+// error semantics.
+func (o *deviceClassCommunicator) getUPSTemperatureSensorValue(ctx context.Context, sensorName string, legacy propertyReader) (float64, error) {
+	if o.components.sensors != nil && o.components.sensors.temperature != nil {
+		if _, ok := o.components.sensors.temperature[sensorName]; ok {
+			sensors, err := o.GetTemperatureSensors(ctx)
+			if err != nil {
+				return 0, err
+			}
+			for _, s := range sensors {
+				if s.Name == sensorName {
+					return s.Value, nil
+				}
+			}
+			return 0, tholaerr.NewPropertyNotModeledError(map[string]interface{}{"property": sensorName, "reason": "sensor declared but missing from readout"})
+		}
+	}
+	return getLegacyUPSFloatProperty(ctx, legacy, sensorName)
+}
+
+func getLegacyUPSFloatProperty(ctx context.Context, prop propertyReader, name string) (float64, error) {
+	if prop == nil {
+		log.Ctx(ctx).Trace().Str("property", name).Msg("no detection information available")
+		return 0, tholaerr.NewPropertyNotModeledError(map[string]interface{}{"property": name})
 	}
-	logger := log.Ctx(ctx).With().Str("property", "UPSComponentSystemVoltage").Logger()
+	logger := log.Ctx(ctx).With().Str("property", name).Logger()
 	ctx = logger.WithContext(ctx)
-	res, err := o.components.ups.systemVoltage.getProperty(ctx)
+	res, err := prop.getProperty(ctx)
 	if err != nil {
 		log.Ctx(ctx).Trace().Err(err).Msg("failed to get property")
-		return 0, errors.Wrap(err, "failed to get UPSComponentSystemVoltage")
+		return 0, tholaerr.NewDetectionError(map[string]interface{}{"property": name}, err)
 	}
 	result, err := res.Float64()
 	if err != nil {
-		return 0, errors.Wrapf(err, "failed to convert result '%v' to float64", res)
+		return 0, tholaerr.NewValueCoercionError(map[string]interface{}{"property": name, "raw_value": res}, err)
 	}
 	return result, nil
 }
 
-func (o *deviceClassCommunicator) getValuesBySNMPWalk(ctx context.Context, oids deviceClassInterfaceOIDs) (map[string]map[string]interface{}, error) {
-	networkInterfaces := make(map[string]map[string]interface{})
+// defaultSNMPWalkConcurrency bounds how many OIDs of a single
+// getValuesBySNMPWalk call are handled by concurrent goroutines when the
+// SNMP connection doesn't override it. network.SNMPConnection serializes
+// every SNMPWalk call on its client, so the walks themselves still run one
+// at a time; only the per-OID normalization runs concurrently.
+const defaultSNMPWalkConcurrency = 4
+
+// snmpWalkConcurrency returns how many OIDs getValuesBySNMPWalk is allowed
+// to walk concurrently for the given SNMP connection.
+func snmpWalkConcurrency(con *network.SNMPConnection) int {
+	if con.MaxWalkConcurrency > 0 {
+		return con.MaxWalkConcurrency
+	}
+	return defaultSNMPWalkConcurrency
+}
 
+// getValuesBySNMPWalk walks every OID in oids, fanning the per-OID work out
+// over a bounded worker pool. The SNMP walks are serialized by
+// network.SNMPConnection, so a slow walk still delays the walks queued
+// behind it. Each OID can declare itself required (the default, aborting the whole
+// readout on error) or optional (logged and skipped on any error, not just
+// tholaerr.IsNotFoundError).
+func (o *deviceClassCommunicator) getValuesBySNMPWalk(ctx context.Context, oids deviceClassInterfaceOIDs) (map[string]map[string]interface{}, error) {
 	con, ok := network.DeviceConnectionFromContext(ctx)
 	if !ok || con.SNMP == nil {
 		log.Ctx(ctx).Trace().Str("property", "interface").Msg("snmp client is empty")
 		return nil, errors.New("snmp client is empty")
 	}
 
+	var (
+		networkInterfaces = make(map[string]map[string]interface{})
+		mu                sync.Mutex
+		wg                sync.WaitGroup
+		sem               = make(chan struct{}, snmpWalkConcurrency(con.SNMP))
+		firstErr          error
+		firstErrOnce      sync.Once
+	)
+
 	for name, oid := range oids {
-		snmpResponse, err := con.SNMP.SnmpClient.SNMPWalk(ctx, string(oid.OID))
-		if err != nil {
-			if tholaerr.IsNotFoundError(err) {
-				log.Ctx(ctx).Trace().Err(err).Msgf("oid %s (%s) not found on device", oid.OID, name)
-				continue
-			}
-			log.Ctx(ctx).Trace().Err(err).Msg("failed to get oid value of interface")
-			return nil, errors.Wrap(err, "failed to get oid value")
-		}
+		name, oid := name, oid
 
-		for _, response := range snmpResponse {
-			res, err := response.GetValueBySNMPGetConfiguration(oid.SNMPGetConfiguration)
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			results, err := o.getOIDValuesBySNMPWalk(ctx, name, oid)
 			if err != nil {
-				log.Ctx(ctx).Trace().Err(err).Msg("couldn't get value from response response")
-				return nil, errors.Wrap(err, "couldn't get value from response response")
-			}
-			if res != "" {
-				resNormalized, err := oid.operators.apply(ctx, value.Value(res))
-				if err != nil {
-					log.Ctx(ctx).Trace().Err(err).Msg("response couldn't be normalized")
-					return nil, errors.Wrap(err, "response couldn't be normalized")
+				if oid.optional || tholaerr.IsSNMPNoSuchObjectError(err) {
+					log.Ctx(ctx).Trace().Err(err).Msgf("oid %s (%s) failed, skipping", oid.OID, name)
+					return
 				}
-				oid := strings.Split(response.GetOID(), ".")
-				ifIndex := oid[len(oid)-1]
+				firstErrOnce.Do(func() { firstErr = err })
+				return
+			}
+
+			mu.Lock()
+			for ifIndex, values := range results {
 				if _, ok := networkInterfaces[ifIndex]; !ok {
 					networkInterfaces[ifIndex] = make(map[string]interface{})
 				}
-				networkInterfaces[ifIndex][name] = resNormalized
+				networkInterfaces[ifIndex][name] = values
 			}
-		}
+			mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
 	}
 
 	return networkInterfaces, nil
 }
+
+// getOIDValuesBySNMPWalk walks a single OID definition and returns its
+// normalized values keyed by ifIndex. It is the per-OID unit of work behind
+// getValuesBySNMPWalk's worker pool.
+func (o *deviceClassCommunicator) getOIDValuesBySNMPWalk(ctx context.Context, name string, oid deviceClassOID) (map[string]interface{}, error) {
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.SNMP == nil {
+		return nil, errors.New("snmp client is empty")
+	}
+
+	fields := map[string]interface{}{"oid": oid.OID, "property": name}
+
+	snmpResponse, err := con.SNMP.SNMPWalk(ctx, string(oid.OID), oid.maxRepetitions)
+	if err != nil {
+		if tholaerr.IsNotFoundError(err) {
+			log.Ctx(ctx).Trace().Err(err).Msgf("oid %s (%s) not found on device", oid.OID, name)
+			return nil, tholaerr.NewSNMPNoSuchObjectError(fields, err)
+		}
+		log.Ctx(ctx).Trace().Err(err).Msg("failed to get oid value of interface")
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, tholaerr.NewSNMPTimeoutError(fields, err)
+		}
+		return nil, errors.Wrap(err, "failed to get oid value")
+	}
+
+	values := make(map[string]interface{})
+
+	for _, response := range snmpResponse {
+		res, err := response.GetValueBySNMPGetConfiguration(oid.SNMPGetConfiguration)
+		if err != nil {
+			log.Ctx(ctx).Trace().Err(err).Msg("couldn't get value from response response")
+			return nil, tholaerr.NewValueCoercionError(fields, err)
+		}
+		if res == "" {
+			continue
+		}
+		resNormalized, err := oid.operators.apply(ctx, value.Value(res))
+		if err != nil {
+			log.Ctx(ctx).Trace().Err(err).Msg("response couldn't be normalized")
+			return nil, tholaerr.NewValueCoercionError(fields, err)
+		}
+		oidParts := strings.Split(response.GetOID(), ".")
+		ifIndex := oidParts[len(oidParts)-1]
+		values[ifIndex] = resNormalized
+	}
+
+	return values, nil
+}
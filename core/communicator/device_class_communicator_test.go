@@ -0,0 +1,169 @@
+package communicator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/inexio/thola/core/network"
+	"github.com/pkg/errors"
+)
+
+// fakeSNMPResponse is a network.SNMPResponse backed by a plain string, used
+// to exercise getValuesBySNMPWalk/getOIDValuesBySNMPWalk without a live SNMP
+// connection.
+type fakeSNMPResponse struct {
+	oid   string
+	value string
+}
+
+func (r fakeSNMPResponse) GetOID() string { return r.oid }
+
+func (r fakeSNMPResponse) GetValue() (interface{}, error) { return r.value, nil }
+
+func (r fakeSNMPResponse) GetValueBySNMPGetConfiguration(_ network.SNMPGetConfiguration) (string, error) {
+	return r.value, nil
+}
+
+// fakeSNMPClient is a network.SnmpClient that serves a fixed number of
+// synthetic interface rows per walked OID, optionally failing specific OIDs
+// and recording the maxRepetitions it was called with.
+type fakeSNMPClient struct {
+	mu          sync.Mutex
+	interfaces  int
+	walkDelay   time.Duration
+	errOIDs     map[string]error
+	maxRepsSeen map[string]int
+}
+
+func (c *fakeSNMPClient) SNMPGet(_ context.Context, oid string) ([]network.SNMPResponse, error) {
+	return []network.SNMPResponse{fakeSNMPResponse{oid: oid, value: "1"}}, nil
+}
+
+func (c *fakeSNMPClient) SNMPWalk(_ context.Context, oid string, maxRepetitions int) ([]network.SNMPResponse, error) {
+	c.mu.Lock()
+	if c.maxRepsSeen != nil {
+		c.maxRepsSeen[oid] = maxRepetitions
+	}
+	err := c.errOIDs[oid]
+	c.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	if c.walkDelay > 0 {
+		time.Sleep(c.walkDelay)
+	}
+
+	responses := make([]network.SNMPResponse, c.interfaces)
+	for i := 0; i < c.interfaces; i++ {
+		responses[i] = fakeSNMPResponse{oid: fmt.Sprintf("%s.%d", oid, i+1), value: "42"}
+	}
+	return responses, nil
+}
+
+func contextWithFakeSNMP(client *fakeSNMPClient, maxWalkConcurrency int) context.Context {
+	return network.NewContextWithDeviceConnection(context.Background(), network.DeviceConnection{
+		Host: "test",
+		SNMP: &network.SNMPConnection{SnmpClient: client, MaxWalkConcurrency: maxWalkConcurrency},
+	})
+}
+
+func TestSnmpWalkConcurrency(t *testing.T) {
+	if got := snmpWalkConcurrency(&network.SNMPConnection{}); got != defaultSNMPWalkConcurrency {
+		t.Fatalf("expected default concurrency %d, got %d", defaultSNMPWalkConcurrency, got)
+	}
+	if got := snmpWalkConcurrency(&network.SNMPConnection{MaxWalkConcurrency: 9}); got != 9 {
+		t.Fatalf("expected overridden concurrency 9, got %d", got)
+	}
+}
+
+func TestGetOIDValuesBySNMPWalk_PassesMaxRepetitions(t *testing.T) {
+	client := &fakeSNMPClient{interfaces: 1, maxRepsSeen: map[string]int{}}
+	ctx := contextWithFakeSNMP(client, 0)
+	o := &deviceClassCommunicator{}
+
+	_, err := o.getOIDValuesBySNMPWalk(ctx, "ifDescr", deviceClassOID{OID: "1.3.6.1.2.1.2.2.1.2", maxRepetitions: 50})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := client.maxRepsSeen["1.3.6.1.2.1.2.2.1.2"]; got != 50 {
+		t.Fatalf("expected maxRepetitions 50 to reach SNMPWalk, got %d", got)
+	}
+}
+
+func TestGetValuesBySNMPWalk_OptionalOIDIsSkippedOnError(t *testing.T) {
+	const brokenOID = "1.3.6.1.2.1.2.2.1.99"
+	client := &fakeSNMPClient{interfaces: 2, errOIDs: map[string]error{brokenOID: errors.New("no such object")}}
+	ctx := contextWithFakeSNMP(client, 0)
+	o := &deviceClassCommunicator{}
+
+	oids := deviceClassInterfaceOIDs{
+		"broken": {OID: brokenOID, optional: true},
+		"ok":     {OID: "1.3.6.1.2.1.2.2.1.2"},
+	}
+
+	result, err := o.getValuesBySNMPWalk(ctx, oids)
+	if err != nil {
+		t.Fatalf("expected optional oid failure to be swallowed, got error: %v", err)
+	}
+	for ifIndex, row := range result {
+		if _, ok := row["broken"]; ok {
+			t.Fatalf("optional oid's failed result leaked into row %s", ifIndex)
+		}
+	}
+}
+
+func TestGetValuesBySNMPWalk_RequiredOIDFailsWholeReadout(t *testing.T) {
+	const brokenOID = "1.3.6.1.2.1.2.2.1.99"
+	client := &fakeSNMPClient{interfaces: 2, errOIDs: map[string]error{brokenOID: errors.New("no such object")}}
+	ctx := contextWithFakeSNMP(client, 0)
+	o := &deviceClassCommunicator{}
+
+	oids := deviceClassInterfaceOIDs{"broken": {OID: brokenOID}}
+
+	if _, err := o.getValuesBySNMPWalk(ctx, oids); err == nil {
+		t.Fatal("expected a required oid's failure to fail the whole readout")
+	}
+}
+
+// syntheticIfTableOIDs builds an ifTable-shaped set of OID definitions, one
+// per typical ifTable column.
+func syntheticIfTableOIDs(columns int) deviceClassInterfaceOIDs {
+	oids := make(deviceClassInterfaceOIDs, columns)
+	for i := 0; i < columns; i++ {
+		oids[fmt.Sprintf("column_%d", i)] = deviceClassOID{OID: fmt.Sprintf("1.3.6.1.2.1.2.2.1.%d", i+1)}
+	}
+	return oids
+}
+
+func benchmarkGetValuesBySNMPWalk(b *testing.B, concurrency int) {
+	oids := syntheticIfTableOIDs(10)
+	client := &fakeSNMPClient{interfaces: 1000, walkDelay: time.Millisecond}
+	ctx := contextWithFakeSNMP(client, concurrency)
+	o := &deviceClassCommunicator{}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := o.getValuesBySNMPWalk(ctx, oids); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkGetValuesBySNMPWalk_Serial pins concurrency to 1, emulating the
+// pre-worker-pool behavior of walking every OID one at a time.
+func BenchmarkGetValuesBySNMPWalk_Serial(b *testing.B) {
+	benchmarkGetValuesBySNMPWalk(b, 1)
+}
+
+// BenchmarkGetValuesBySNMPWalk_Concurrent uses the default bounded worker
+// pool against a synthetic ~1000-interface ifTable (10 columns x 1000
+// rows). network.SNMPConnection serializes the SNMPWalk calls, so the
+// simulated 1ms walk delay is paid once per column as in the serial
+// benchmark; only the per-row normalization of the 10 columns overlaps.
+func BenchmarkGetValuesBySNMPWalk_Concurrent(b *testing.B) {
+	benchmarkGetValuesBySNMPWalk(b, defaultSNMPWalkConcurrency)
+}
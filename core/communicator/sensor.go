@@ -0,0 +1,298 @@
+package communicator
+
+import (
+	"context"
+	"strings"
+
+	"github.com/inexio/thola/core/device"
+	"github.com/inexio/thola/core/network"
+	"github.com/inexio/thola/core/tholaerr"
+	"github.com/inexio/thola/core/value"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// PowerSensorsGetter is implemented by communicators that can read out
+// voltage, current and power sensors, e.g. UPS battery rails or PDU outlets.
+type PowerSensorsGetter interface {
+	GetPowerSensors(ctx context.Context) ([]device.PowerSensor, error)
+}
+
+// TemperatureSensorsGetter is implemented by communicators that can read out
+// temperature sensors.
+type TemperatureSensorsGetter interface {
+	GetTemperatureSensors(ctx context.Context) ([]device.TemperatureSensor, error)
+}
+
+// FanSensorsGetter is implemented by communicators that can read out fan
+// speed sensors.
+type FanSensorsGetter interface {
+	GetFanSensors(ctx context.Context) ([]device.FanSensor, error)
+}
+
+// ChassisHealthGetter is implemented by communicators that can read out the
+// aggregated health status of a chassis or its sub-components.
+type ChassisHealthGetter interface {
+	GetChassisHealth(ctx context.Context) ([]device.ChassisHealth, error)
+}
+
+// deviceClassSensors declares the OID-based sensors a device class exposes,
+// grouped by category. Vendors only need to list their sensors here; the
+// generic readout in deviceClassCommunicator below walks the declared OIDs
+// and normalizes them into the device.*Sensor types, so no new Go code has
+// to be added for a new vendor or component class.
+type deviceClassSensors struct {
+	power       deviceClassSensorOIDs
+	temperature deviceClassSensorOIDs
+	fan         deviceClassSensorOIDs
+	chassis     deviceClassSensorOIDs
+}
+
+// deviceClassSensorOID declares a single sensor reading: the OID to walk,
+// how to turn each returned row into a normalized value, and the metadata
+// (unit, location, status/threshold normalization) needed to populate one of
+// the device.*Sensor types.
+type deviceClassSensorOID struct {
+	oid        deviceClassOID
+	unit       string
+	location   string
+	thresholds deviceClassSensorThresholdOIDs
+
+	// statusValues maps this OID's raw (post-operator) value to a
+	// device.SensorStatus, for sensors whose reading is already a status
+	// code rather than a thresholded measurement - currently only
+	// GetChassisHealth uses it, since a chassis health OID typically
+	// returns an enum like "1"/"2"/"3" rather than a value
+	// sensorStatusFromThresholds could check numerically. Readings with no matching entry (or sensors
+	// that declare no table at all) report device.SensorStatusUnknown.
+	statusValues map[string]device.SensorStatus
+}
+
+// deviceClassSensorThresholdOIDs optionally declares OIDs for the alarm
+// thresholds belonging to a sensor reading. Any of them may be left empty if
+// the device class does not model that threshold.
+type deviceClassSensorThresholdOIDs struct {
+	lowerCritical value.OID
+	lowerWarning  value.OID
+	upperWarning  value.OID
+	upperCritical value.OID
+}
+
+// deviceClassSensorOIDs maps a sensor name (used as its device.*Sensor.Name)
+// to its OID walk definition. A walk returning multiple rows (e.g. one PDU
+// outlet per row) produces one device.*Sensor per row, all sharing Name but
+// distinguished by Index (see readSensors).
+type deviceClassSensorOIDs map[string]deviceClassSensorOID
+
+func (o *deviceClassCommunicator) GetPowerSensors(ctx context.Context) ([]device.PowerSensor, error) {
+	if o.components.sensors == nil || o.components.sensors.power == nil {
+		log.Ctx(ctx).Trace().Str("property", "power_sensors").Msg("no power sensor information available")
+		return nil, tholaerr.NewNotImplementedError("not implemented")
+	}
+
+	var sensors []device.PowerSensor
+	err := o.readSensors(ctx, o.components.sensors.power, func(name, index string, val value.Value, thresholds device.SensorThresholds, _ map[string]device.SensorStatus, unit, location string) {
+		f, _ := val.Float64()
+		sensors = append(sensors, device.PowerSensor{
+			Name:       name,
+			Index:      index,
+			Location:   location,
+			Value:      f,
+			Unit:       unit,
+			Status:     sensorStatusFromThresholds(f, thresholds),
+			Thresholds: thresholds,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sensors, nil
+}
+
+func (o *deviceClassCommunicator) GetTemperatureSensors(ctx context.Context) ([]device.TemperatureSensor, error) {
+	if o.components.sensors == nil || o.components.sensors.temperature == nil {
+		log.Ctx(ctx).Trace().Str("property", "temperature_sensors").Msg("no temperature sensor information available")
+		return nil, tholaerr.NewNotImplementedError("not implemented")
+	}
+
+	var sensors []device.TemperatureSensor
+	err := o.readSensors(ctx, o.components.sensors.temperature, func(name, index string, val value.Value, thresholds device.SensorThresholds, _ map[string]device.SensorStatus, unit, location string) {
+		f, _ := val.Float64()
+		sensors = append(sensors, device.TemperatureSensor{
+			Name:       name,
+			Index:      index,
+			Location:   location,
+			Value:      f,
+			Unit:       unit,
+			Status:     sensorStatusFromThresholds(f, thresholds),
+			Thresholds: thresholds,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sensors, nil
+}
+
+func (o *deviceClassCommunicator) GetFanSensors(ctx context.Context) ([]device.FanSensor, error) {
+	if o.components.sensors == nil || o.components.sensors.fan == nil {
+		log.Ctx(ctx).Trace().Str("property", "fan_sensors").Msg("no fan sensor information available")
+		return nil, tholaerr.NewNotImplementedError("not implemented")
+	}
+
+	var sensors []device.FanSensor
+	err := o.readSensors(ctx, o.components.sensors.fan, func(name, index string, val value.Value, thresholds device.SensorThresholds, _ map[string]device.SensorStatus, unit, location string) {
+		f, _ := val.Float64()
+		sensors = append(sensors, device.FanSensor{
+			Name:       name,
+			Index:      index,
+			Location:   location,
+			Value:      f,
+			Unit:       unit,
+			Status:     sensorStatusFromThresholds(f, thresholds),
+			Thresholds: thresholds,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sensors, nil
+}
+
+func (o *deviceClassCommunicator) GetChassisHealth(ctx context.Context) ([]device.ChassisHealth, error) {
+	if o.components.sensors == nil || o.components.sensors.chassis == nil {
+		log.Ctx(ctx).Trace().Str("property", "chassis_health").Msg("no chassis health information available")
+		return nil, tholaerr.NewNotImplementedError("not implemented")
+	}
+
+	var health []device.ChassisHealth
+	err := o.readSensors(ctx, o.components.sensors.chassis, func(name, index string, val value.Value, _ device.SensorThresholds, statusValues map[string]device.SensorStatus, _, location string) {
+		health = append(health, device.ChassisHealth{
+			Name:     name,
+			Index:    index,
+			Location: location,
+			Status:   chassisStatusFromValue(val, statusValues),
+			Message:  val.String(),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return health, nil
+}
+
+// readSensors walks every OID declared in oids and hands each normalized
+// reading to add, one call per returned row. It is the shared implementation
+// behind GetPowerSensors, GetTemperatureSensors, GetFanSensors and
+// GetChassisHealth.
+func (o *deviceClassCommunicator) readSensors(ctx context.Context, oids deviceClassSensorOIDs, add func(name, index string, val value.Value, thresholds device.SensorThresholds, statusValues map[string]device.SensorStatus, unit, location string)) error {
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.SNMP == nil {
+		log.Ctx(ctx).Trace().Str("property", "sensor").Msg("snmp client is empty")
+		return errors.New("snmp client is empty")
+	}
+
+	for name, sensorOID := range oids {
+		snmpResponse, err := con.SNMP.SNMPWalk(ctx, string(sensorOID.oid.OID), sensorOID.oid.maxRepetitions)
+		if err != nil {
+			if tholaerr.IsNotFoundError(err) {
+				log.Ctx(ctx).Trace().Err(tholaerr.NewSNMPNoSuchObjectError(map[string]interface{}{"oid": sensorOID.oid.OID, "property": name}, err)).Msgf("oid %s (%s) not found on device", sensorOID.oid.OID, name)
+				continue
+			}
+			log.Ctx(ctx).Trace().Err(err).Msg("failed to get oid value of sensor")
+			return errors.Wrap(err, "failed to get oid value")
+		}
+
+		// Thresholds are scalar OIDs, not one-per-row, so read them once per
+		// sensor definition rather than once per walked row.
+		thresholds := o.readSensorThresholds(ctx, sensorOID.thresholds)
+
+		for _, response := range snmpResponse {
+			res, err := response.GetValueBySNMPGetConfiguration(sensorOID.oid.SNMPGetConfiguration)
+			if err != nil {
+				log.Ctx(ctx).Trace().Err(err).Msg("couldn't get value from response")
+				return errors.Wrap(err, "couldn't get value from response")
+			}
+			if res == "" {
+				continue
+			}
+			resNormalized, err := sensorOID.oid.operators.apply(ctx, value.Value(res))
+			if err != nil {
+				log.Ctx(ctx).Trace().Err(err).Msg("response couldn't be normalized")
+				return errors.Wrap(err, "response couldn't be normalized")
+			}
+			oidParts := strings.Split(response.GetOID(), ".")
+			index := oidParts[len(oidParts)-1]
+			add(name, index, resNormalized, thresholds, sensorOID.statusValues, sensorOID.unit, sensorOID.location)
+		}
+	}
+
+	return nil
+}
+
+// readSensorThresholds reads the (optional) threshold OIDs declared for a
+// single sensor. Thresholds that are not declared, or that fail to read, are
+// simply left nil so the resulting device.SensorThresholds stays partial
+// rather than failing the whole sensor readout.
+func (o *deviceClassCommunicator) readSensorThresholds(ctx context.Context, thresholdOIDs deviceClassSensorThresholdOIDs) device.SensorThresholds {
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.SNMP == nil {
+		return device.SensorThresholds{}
+	}
+
+	read := func(oid value.OID) *float64 {
+		if oid == "" {
+			return nil
+		}
+		resp, err := con.SNMP.SNMPGet(ctx, string(oid))
+		if err != nil || len(resp) == 0 {
+			return nil
+		}
+		val, err := resp[0].GetValue()
+		if err != nil {
+			return nil
+		}
+		f, err := value.Value(value.New(val)).Float64()
+		if err != nil {
+			return nil
+		}
+		return &f
+	}
+
+	return device.SensorThresholds{
+		LowerCritical: read(thresholdOIDs.lowerCritical),
+		LowerWarning:  read(thresholdOIDs.lowerWarning),
+		UpperWarning:  read(thresholdOIDs.upperWarning),
+		UpperCritical: read(thresholdOIDs.upperCritical),
+	}
+}
+
+// chassisStatusFromValue maps a chassis health reading to a
+// device.SensorStatus via the device class's declared statusValues table,
+// since a chassis health OID is a status code (e.g. an SNMP operational
+// state enum), not a thresholded measurement - sensorStatusFromThresholds
+// would otherwise always report SensorStatusOK for it. A reading with no
+// matching entry, or a sensor with no table declared at all, reports
+// SensorStatusUnknown rather than silently claiming OK.
+func chassisStatusFromValue(val value.Value, statusValues map[string]device.SensorStatus) device.SensorStatus {
+	if status, ok := statusValues[val.String()]; ok {
+		return status
+	}
+	return device.SensorStatusUnknown
+}
+
+// sensorStatusFromThresholds derives a device.SensorStatus from a reading and
+// its (possibly partial) thresholds. Missing thresholds are simply not
+// checked, so a sensor without any declared thresholds is always reported OK.
+func sensorStatusFromThresholds(val float64, thresholds device.SensorThresholds) device.SensorStatus {
+	switch {
+	case thresholds.LowerCritical != nil && val <= *thresholds.LowerCritical,
+		thresholds.UpperCritical != nil && val >= *thresholds.UpperCritical:
+		return device.SensorStatusCritical
+	case thresholds.LowerWarning != nil && val <= *thresholds.LowerWarning,
+		thresholds.UpperWarning != nil && val >= *thresholds.UpperWarning:
+		return device.SensorStatusWarning
+	default:
+		return device.SensorStatusOK
+	}
+}
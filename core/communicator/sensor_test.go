@@ -0,0 +1,126 @@
+package communicator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/inexio/thola/core/device"
+	"github.com/inexio/thola/core/network"
+	"github.com/inexio/thola/core/value"
+)
+
+func TestSensorStatusFromThresholds(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name       string
+		val        float64
+		thresholds device.SensorThresholds
+		want       device.SensorStatus
+	}{
+		{"no thresholds", 42, device.SensorThresholds{}, device.SensorStatusOK},
+		{"within bounds", 50, device.SensorThresholds{LowerWarning: f(10), UpperWarning: f(90), LowerCritical: f(0), UpperCritical: f(100)}, device.SensorStatusOK},
+		{"above upper warning", 95, device.SensorThresholds{UpperWarning: f(90), UpperCritical: f(100)}, device.SensorStatusWarning},
+		{"at upper critical", 100, device.SensorThresholds{UpperWarning: f(90), UpperCritical: f(100)}, device.SensorStatusCritical},
+		{"below lower critical", -1, device.SensorThresholds{LowerWarning: f(0), LowerCritical: f(0)}, device.SensorStatusCritical},
+		{"below lower warning only", -1, device.SensorThresholds{LowerWarning: f(0)}, device.SensorStatusWarning},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sensorStatusFromThresholds(tt.val, tt.thresholds); got != tt.want {
+				t.Errorf("sensorStatusFromThresholds(%v, %+v) = %v, want %v", tt.val, tt.thresholds, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChassisStatusFromValue(t *testing.T) {
+	statusValues := map[string]device.SensorStatus{
+		"1": device.SensorStatusOK,
+		"2": device.SensorStatusWarning,
+		"3": device.SensorStatusCritical,
+	}
+
+	if got := chassisStatusFromValue(value.Value("3"), statusValues); got != device.SensorStatusCritical {
+		t.Errorf("chassisStatusFromValue(3, ...) = %v, want %v", got, device.SensorStatusCritical)
+	}
+	if got := chassisStatusFromValue(value.Value("unmapped"), statusValues); got != device.SensorStatusUnknown {
+		t.Errorf("chassisStatusFromValue with no matching entry = %v, want %v", got, device.SensorStatusUnknown)
+	}
+	if got := chassisStatusFromValue(value.Value("1"), nil); got != device.SensorStatusUnknown {
+		t.Errorf("chassisStatusFromValue with no declared table = %v, want %v", got, device.SensorStatusUnknown)
+	}
+}
+
+// rowCountingSNMPClient serves a fixed number of synthetic table rows from
+// SNMPWalk, each distinguished by a trailing ".<row>" OID index, and counts
+// how many times SNMPGet was called so tests can confirm threshold scalars
+// are read once per sensor rather than once per row.
+type rowCountingSNMPClient struct {
+	rows int
+
+	mu       sync.Mutex
+	getCalls int
+}
+
+func (c *rowCountingSNMPClient) SNMPGet(_ context.Context, oid string) ([]network.SNMPResponse, error) {
+	c.mu.Lock()
+	c.getCalls++
+	c.mu.Unlock()
+	return []network.SNMPResponse{fakeSNMPResponse{oid: oid, value: "50"}}, nil
+}
+
+func (c *rowCountingSNMPClient) SNMPWalk(_ context.Context, oid string, _ int) ([]network.SNMPResponse, error) {
+	responses := make([]network.SNMPResponse, c.rows)
+	for i := 0; i < c.rows; i++ {
+		responses[i] = fakeSNMPResponse{oid: fmt.Sprintf("%s.%d", oid, i+1), value: "10"}
+	}
+	return responses, nil
+}
+
+func TestReadSensors_RowsGetDistinctIndexAndSharedThresholds(t *testing.T) {
+	client := &rowCountingSNMPClient{rows: 3}
+	ctx := network.NewContextWithDeviceConnection(context.Background(), network.DeviceConnection{
+		Host: "test",
+		SNMP: &network.SNMPConnection{SnmpClient: client},
+	})
+
+	o := &deviceClassCommunicator{}
+	oids := deviceClassSensorOIDs{
+		"outlet": {
+			oid:        deviceClassOID{OID: "1.3.6.1.4.1.9.9.1"},
+			thresholds: deviceClassSensorThresholdOIDs{upperWarning: "1.3.6.1.4.1.9.9.2"},
+		},
+	}
+
+	var indexes []string
+	err := o.readSensors(ctx, oids, func(name, index string, val value.Value, thresholds device.SensorThresholds, _ map[string]device.SensorStatus, _, _ string) {
+		if name != "outlet" {
+			t.Errorf("name = %q, want %q", name, "outlet")
+		}
+		indexes = append(indexes, index)
+		if thresholds.UpperWarning == nil || *thresholds.UpperWarning != 50 {
+			t.Errorf("thresholds.UpperWarning = %v, want 50", thresholds.UpperWarning)
+		}
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantIndexes := []string{"1", "2", "3"}
+	if len(indexes) != len(wantIndexes) {
+		t.Fatalf("got %d readings, want %d", len(indexes), len(wantIndexes))
+	}
+	for i, want := range wantIndexes {
+		if indexes[i] != want {
+			t.Errorf("indexes[%d] = %q, want %q", i, indexes[i], want)
+		}
+	}
+
+	if client.getCalls != 1 {
+		t.Errorf("expected thresholds to be read once per sensor regardless of row count, got %d SNMPGet calls", client.getCalls)
+	}
+}
@@ -0,0 +1,18 @@
+package device
+
+// Interface is a single network interface readout, assembled from the
+// ifTable walk plus any per-type overlay values a device class declares
+// (see deviceClassComponentsInterfaces in the communicator package).
+type Interface struct {
+	IfIndex       *int    `json:"ifIndex,omitempty" xml:"ifIndex,omitempty"`
+	IfDescr       *string `json:"ifDescr,omitempty" xml:"ifDescr,omitempty"`
+	IfType        *string `json:"ifType,omitempty" xml:"ifType,omitempty"`
+	IfSpeed       *uint64 `json:"ifSpeed,omitempty" xml:"ifSpeed,omitempty"`
+	IfPhysAddress *string `json:"ifPhysAddress,omitempty" xml:"ifPhysAddress,omitempty"`
+	IfAdminStatus *string `json:"ifAdminStatus,omitempty" xml:"ifAdminStatus,omitempty"`
+	IfOperStatus  *string `json:"ifOperStatus,omitempty" xml:"ifOperStatus,omitempty"`
+	IfInOctets    *uint64 `json:"ifInOctets,omitempty" xml:"ifInOctets,omitempty"`
+	IfOutOctets   *uint64 `json:"ifOutOctets,omitempty" xml:"ifOutOctets,omitempty"`
+	IfInErrors    *uint64 `json:"ifInErrors,omitempty" xml:"ifInErrors,omitempty"`
+	IfOutErrors   *uint64 `json:"ifOutErrors,omitempty" xml:"ifOutErrors,omitempty"`
+}
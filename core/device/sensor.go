@@ -0,0 +1,77 @@
+package device
+
+// SensorStatus represents the operational status reported for a single
+// hardware sensor reading (power, temperature, fan, chassis health, ...).
+type SensorStatus string
+
+const (
+	SensorStatusOK       SensorStatus = "ok"
+	SensorStatusWarning  SensorStatus = "warning"
+	SensorStatusCritical SensorStatus = "critical"
+	SensorStatusUnknown  SensorStatus = "unknown"
+)
+
+// SensorThresholds holds the alarm thresholds associated with a sensor
+// reading, if the device class declares them. Any of the fields may be nil
+// if the corresponding threshold is not modeled for the sensor.
+type SensorThresholds struct {
+	LowerCritical *float64 `json:"lower_critical,omitempty" xml:"lower_critical,omitempty"`
+	LowerWarning  *float64 `json:"lower_warning,omitempty" xml:"lower_warning,omitempty"`
+	UpperWarning  *float64 `json:"upper_warning,omitempty" xml:"upper_warning,omitempty"`
+	UpperCritical *float64 `json:"upper_critical,omitempty" xml:"upper_critical,omitempty"`
+}
+
+// PowerSensor represents a single voltage, current or power reading exposed
+// by a device, e.g. a UPS battery rail or a PDU outlet.
+type PowerSensor struct {
+	Name string `json:"name" xml:"name"`
+	// Index is the SNMP table row this reading came from (the last OID
+	// element of the walk result), so multiple rows of the same declared
+	// sensor - e.g. one reading per PDU outlet - stay distinguishable.
+	Index      string           `json:"index,omitempty" xml:"index,omitempty"`
+	Location   string           `json:"location,omitempty" xml:"location,omitempty"`
+	Value      float64          `json:"value" xml:"value"`
+	Unit       string           `json:"unit" xml:"unit"`
+	Status     SensorStatus     `json:"status" xml:"status"`
+	Thresholds SensorThresholds `json:"thresholds,omitempty" xml:"thresholds,omitempty"`
+}
+
+// TemperatureSensor represents a single temperature reading exposed by a
+// device, e.g. a UPS battery probe or a chassis inlet sensor.
+type TemperatureSensor struct {
+	Name string `json:"name" xml:"name"`
+	// Index is the SNMP table row this reading came from; see
+	// PowerSensor.Index.
+	Index      string           `json:"index,omitempty" xml:"index,omitempty"`
+	Location   string           `json:"location,omitempty" xml:"location,omitempty"`
+	Value      float64          `json:"value" xml:"value"`
+	Unit       string           `json:"unit" xml:"unit"`
+	Status     SensorStatus     `json:"status" xml:"status"`
+	Thresholds SensorThresholds `json:"thresholds,omitempty" xml:"thresholds,omitempty"`
+}
+
+// FanSensor represents a single fan speed reading exposed by a device.
+type FanSensor struct {
+	Name string `json:"name" xml:"name"`
+	// Index is the SNMP table row this reading came from; see
+	// PowerSensor.Index.
+	Index      string           `json:"index,omitempty" xml:"index,omitempty"`
+	Location   string           `json:"location,omitempty" xml:"location,omitempty"`
+	Value      float64          `json:"value" xml:"value"`
+	Unit       string           `json:"unit" xml:"unit"`
+	Status     SensorStatus     `json:"status" xml:"status"`
+	Thresholds SensorThresholds `json:"thresholds,omitempty" xml:"thresholds,omitempty"`
+}
+
+// ChassisHealth represents the aggregated health status of a chassis or one
+// of its sub-components (power supply, module slot, ...) as a single
+// status/message pair, independent of any single numeric reading.
+type ChassisHealth struct {
+	Name string `json:"name" xml:"name"`
+	// Index is the SNMP table row this reading came from; see
+	// PowerSensor.Index.
+	Index    string       `json:"index,omitempty" xml:"index,omitempty"`
+	Location string       `json:"location,omitempty" xml:"location,omitempty"`
+	Status   SensorStatus `json:"status" xml:"status"`
+	Message  string       `json:"message,omitempty" xml:"message,omitempty"`
+}
@@ -0,0 +1,119 @@
+// Package network holds the per-request device connection (SNMP client,
+// credentials, tuning knobs) that communicators pull out of the request
+// context, plus the response types an SNMP get/walk hands back.
+package network
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+)
+
+// SNMPGetConfiguration describes how to pull a single normalized value out
+// of one SNMPResponse row (which part of the varbind to use and how to
+// decode it). Device classes attach one to every OID they declare.
+type SNMPGetConfiguration struct {
+	// UseRawResult bypasses any type-specific decoding and returns the
+	// varbind's value verbatim.
+	UseRawResult bool
+}
+
+// SNMPResponse is a single varbind returned from an SNMP get or walk.
+type SNMPResponse interface {
+	// GetOID returns the full OID this row was read from, e.g. for a walk
+	// result the last element is the table row's index.
+	GetOID() string
+	// GetValue returns the decoded value using the response's native SNMP
+	// type (int, string, ...).
+	GetValue() (interface{}, error)
+	// GetValueBySNMPGetConfiguration returns the value decoded according to
+	// config, as a string ready to be wrapped in a value.Value.
+	GetValueBySNMPGetConfiguration(config SNMPGetConfiguration) (string, error)
+}
+
+// SnmpClient is the minimal SNMP transport a communicator needs: get a
+// single OID, or walk a subtree with a caller-chosen bulk-walk page size.
+type SnmpClient interface {
+	SNMPGet(ctx context.Context, oid string) ([]SNMPResponse, error)
+	SNMPWalk(ctx context.Context, oid string, maxRepetitions int) ([]SNMPResponse, error)
+}
+
+// SNMPConnection bundles the transport for a single device's SNMP session
+// with the credentials used to establish it and any per-connection tuning
+// overrides.
+//
+// A SnmpClient implementation is generally not safe for concurrent use: a
+// real SNMP session has one UDP socket and generates its own request IDs,
+// so concurrent Get/Walk calls on the same client can interleave or
+// corrupt each other's responses. SNMPConnection itself IS safe for
+// concurrent use - call SNMPGet/SNMPWalk on it rather than on SnmpClient
+// directly, and it serializes access to SnmpClient for you. This lets
+// getValuesBySNMPWalk's worker pool fan MaxWalkConcurrency OIDs out across
+// goroutines without every SnmpClient implementation having to be
+// concurrency-safe itself.
+type SNMPConnection struct {
+	SnmpClient SnmpClient
+
+	// Version and Community identify the session for CredentialsHash; they
+	// are not otherwise interpreted here.
+	Version   string
+	Community string
+
+	// MaxWalkConcurrency overrides getValuesBySNMPWalk's bounded worker
+	// pool size for this connection. Zero keeps the communicator's default
+	// (see communicator.defaultSNMPWalkConcurrency). Since SNMPGet/SNMPWalk
+	// serialize all calls through mu, raising this controls how many
+	// walks can be queued up waiting on the transport, not how many run
+	// against it at once.
+	MaxWalkConcurrency int
+
+	mu sync.Mutex
+}
+
+// CredentialsHash returns a stable, opaque digest of the credentials this
+// connection was established with, so two different credential sets against
+// the same host never collide in a cache key.
+func (c *SNMPConnection) CredentialsHash() string {
+	sum := sha256.Sum256([]byte(c.Version + ":" + c.Community))
+	return hex.EncodeToString(sum[:])
+}
+
+// SNMPGet gets oid, serializing access to SnmpClient so concurrent callers
+// can't race on the underlying transport.
+func (c *SNMPConnection) SNMPGet(ctx context.Context, oid string) ([]SNMPResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.SnmpClient.SNMPGet(ctx, oid)
+}
+
+// SNMPWalk walks oid, serializing access to SnmpClient for the same reason
+// as SNMPGet.
+func (c *SNMPConnection) SNMPWalk(ctx context.Context, oid string, maxRepetitions int) ([]SNMPResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.SnmpClient.SNMPWalk(ctx, oid, maxRepetitions)
+}
+
+// DeviceConnection is the per-request connection state a communicator reads
+// out of the request context: the target host plus whichever protocol
+// connections (currently just SNMP) were established for it.
+type DeviceConnection struct {
+	Host string
+	SNMP *SNMPConnection
+}
+
+type deviceConnectionCtxKey struct{}
+
+// NewContextWithDeviceConnection returns a copy of ctx carrying con, for
+// DeviceConnectionFromContext to read back later in the same request.
+func NewContextWithDeviceConnection(ctx context.Context, con DeviceConnection) context.Context {
+	return context.WithValue(ctx, deviceConnectionCtxKey{}, con)
+}
+
+// DeviceConnectionFromContext returns the DeviceConnection stashed in ctx by
+// NewContextWithDeviceConnection, if any.
+func DeviceConnectionFromContext(ctx context.Context) (DeviceConnection, bool) {
+	con, ok := ctx.Value(deviceConnectionCtxKey{}).(DeviceConnection)
+	return con, ok
+}
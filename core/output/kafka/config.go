@@ -0,0 +1,44 @@
+package kafka
+
+import "time"
+
+// Config configures a Publisher: broker list, topic, client id, TLS and
+// SASL settings, and the batching/retry behavior.
+type Config struct {
+	Brokers  []string
+	Topic    string
+	ClientID string
+
+	TLS  TLSConfig
+	SASL SASLConfig
+
+	// BatchSize is the number of readouts buffered before they are flushed
+	// to the broker as one batch. Defaults to 100 if unset.
+	BatchSize int
+	// BatchTimeout bounds how long a partially filled batch is held before
+	// being flushed anyway. Defaults to 1s if unset.
+	BatchTimeout time.Duration
+	// MaxRetries is how many times a failed batch is retried before it is
+	// dropped and logged. Defaults to 3 if unset.
+	MaxRetries int
+	// RetryBackoff is the base delay between retries of a failed batch,
+	// doubled after each attempt. Defaults to 500ms if unset.
+	RetryBackoff time.Duration
+}
+
+// TLSConfig configures TLS for the Kafka connection.
+type TLSConfig struct {
+	Enabled  bool
+	CAFile   string
+	CertFile string
+	KeyFile  string
+	Insecure bool
+}
+
+// SASLConfig configures SASL authentication for the Kafka connection.
+type SASLConfig struct {
+	Enabled   bool
+	Mechanism string // "PLAIN", "SCRAM-SHA-256" or "SCRAM-SHA-512"
+	Username  string
+	Password  string
+}
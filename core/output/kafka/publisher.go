@@ -0,0 +1,223 @@
+// Package kafka publishes completed readouts to a Kafka topic via Publisher.
+//
+// This package is self-contained: it does not wire itself into `thola read
+// all` or any other command. A caller builds a Publisher with NewPublisher,
+// maps each completed readout to a Message with FromReadout, and calls
+// Publish - there is no --output kafka/--brokers/--topic flag anywhere in
+// this repo that does that for you, because there is no cmd/command package
+// in this checkout to add one to.
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+const (
+	defaultBatchSize    = 100
+	defaultBatchTimeout = time.Second
+	defaultMaxRetries   = 3
+	defaultRetryBackoff = 500 * time.Millisecond
+
+	writeTimeout = 10 * time.Second
+)
+
+// Message is the JSON shape published for a single completed readout. It
+// mirrors the existing REST readout response shape (identity, interfaces,
+// per-component sections) so consumers don't need a separate schema for
+// "streamed" vs "polled" data. Use FromReadout to build one from a
+// completed readout's results.
+type Message struct {
+	Host       string      `json:"host"`
+	Timestamp  time.Time   `json:"timestamp"`
+	Identify   interface{} `json:"identify,omitempty"`
+	Interfaces interface{} `json:"interfaces,omitempty"`
+	UPS        interface{} `json:"ups,omitempty"`
+	Sensors    interface{} `json:"sensors,omitempty"`
+}
+
+// FromReadout builds the Message for a single completed readout. identify,
+// interfaces, ups and sensors are whatever (possibly nil, if that component
+// wasn't read out or isn't modeled) structures the corresponding `thola
+// read` results carry, passed through as-is so the published shape matches
+// the REST response 1:1.
+func FromReadout(host string, timestamp time.Time, identify, interfaces, ups, sensors interface{}) Message {
+	return Message{
+		Host:       host,
+		Timestamp:  timestamp,
+		Identify:   identify,
+		Interfaces: interfaces,
+		UPS:        ups,
+		Sensors:    sensors,
+	}
+}
+
+// Publisher publishes completed readouts to a Kafka topic. Readouts are
+// queued and flushed in batches on a background goroutine so a burst of
+// readouts doesn't block the SNMP polling path on the broker round trip.
+type Publisher struct {
+	config Config
+	writer *kafkago.Writer
+
+	queue chan Message
+	done  chan struct{}
+	wg    sync.WaitGroup
+
+	dropped uint64
+}
+
+// NewPublisher creates a Publisher and starts its background batching loop.
+// Callers must call Close to flush any buffered readouts and stop the loop.
+func NewPublisher(config Config) (*Publisher, error) {
+	if len(config.Brokers) == 0 {
+		return nil, errors.New("kafka: at least one broker is required")
+	}
+	if config.Topic == "" {
+		return nil, errors.New("kafka: topic is required")
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaultBatchSize
+	}
+	if config.BatchTimeout <= 0 {
+		config.BatchTimeout = defaultBatchTimeout
+	}
+	if config.MaxRetries <= 0 {
+		config.MaxRetries = defaultMaxRetries
+	}
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = defaultRetryBackoff
+	}
+
+	transport, err := newTransport(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to configure kafka transport")
+	}
+
+	p := &Publisher{
+		config: config,
+		writer: &kafkago.Writer{
+			Addr:      kafkago.TCP(config.Brokers...),
+			Topic:     config.Topic,
+			Balancer:  &kafkago.Hash{},
+			Transport: transport,
+		},
+		queue: make(chan Message, config.BatchSize*2),
+		done:  make(chan struct{}),
+	}
+
+	p.wg.Add(1)
+	go p.run()
+
+	return p, nil
+}
+
+// Publish enqueues a completed readout to be sent to Kafka, keyed by its
+// target host so all readouts for one device land on the same partition.
+// It never blocks: the consumer goroutine can be stuck retrying a struggling
+// broker for many seconds (see sendWithRetry), and a blocking Publish would
+// propagate that stall straight into the SNMP polling path once the queue
+// filled up. Instead, a full queue means the readout is dropped immediately
+// and counted in DroppedMessages, so publishing to Kafka can only ever be as
+// slow as a channel send, never as slow as the broker.
+func (p *Publisher) Publish(ctx context.Context, msg Message) error {
+	select {
+	case p.queue <- msg:
+		return nil
+	default:
+		atomic.AddUint64(&p.dropped, 1)
+		log.Ctx(ctx).Warn().Str("host", msg.Host).Msg("kafka publish queue full, dropping readout")
+		return nil
+	}
+}
+
+// DroppedMessages returns how many readouts have been dropped so far
+// because the publish queue was full when Publish was called.
+func (p *Publisher) DroppedMessages() uint64 {
+	return atomic.LoadUint64(&p.dropped)
+}
+
+// Close flushes any buffered readouts and stops the Publisher.
+func (p *Publisher) Close() error {
+	close(p.done)
+	p.wg.Wait()
+	return p.writer.Close()
+}
+
+func (p *Publisher) run() {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.config.BatchTimeout)
+	defer ticker.Stop()
+
+	var batch []Message
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		p.sendWithRetry(batch)
+		batch = nil
+	}
+
+	for {
+		select {
+		case msg := <-p.queue:
+			batch = append(batch, msg)
+			if len(batch) >= p.config.BatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-p.done:
+			for {
+				select {
+				case msg := <-p.queue:
+					batch = append(batch, msg)
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// sendWithRetry writes a batch to Kafka, retrying with exponential backoff
+// up to config.MaxRetries times before dropping the batch and logging the
+// loss.
+func (p *Publisher) sendWithRetry(batch []Message) {
+	messages := make([]kafkago.Message, 0, len(batch))
+	for _, msg := range batch {
+		raw, err := json.Marshal(msg)
+		if err != nil {
+			log.Error().Err(err).Str("host", msg.Host).Msg("failed to marshal readout for kafka publish")
+			continue
+		}
+		messages = append(messages, kafkago.Message{Key: []byte(msg.Host), Value: raw})
+	}
+	if len(messages) == 0 {
+		return
+	}
+
+	var err error
+	backoff := p.config.RetryBackoff
+	for attempt := 0; attempt <= p.config.MaxRetries; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), writeTimeout)
+		err = p.writer.WriteMessages(ctx, messages...)
+		cancel()
+		if err == nil {
+			return
+		}
+		log.Warn().Err(err).Int("attempt", attempt+1).Msg("failed to publish readouts to kafka, retrying")
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+	log.Error().Err(err).Int("messages", len(messages)).Msg("dropping readouts after exhausting kafka publish retries")
+}
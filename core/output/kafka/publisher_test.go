@@ -0,0 +1,52 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestFromReadout(t *testing.T) {
+	ts := time.Unix(1700000000, 0)
+	msg := FromReadout("host1", ts, "vendor-x", []int{1, 2}, nil, nil)
+
+	if msg.Host != "host1" {
+		t.Errorf("Host = %q, want %q", msg.Host, "host1")
+	}
+	if !msg.Timestamp.Equal(ts) {
+		t.Errorf("Timestamp = %v, want %v", msg.Timestamp, ts)
+	}
+	if msg.Identify != "vendor-x" {
+		t.Errorf("Identify = %v, want %q", msg.Identify, "vendor-x")
+	}
+	if msg.UPS != nil || msg.Sensors != nil {
+		t.Errorf("expected UPS and Sensors to stay nil when not read out, got %v / %v", msg.UPS, msg.Sensors)
+	}
+}
+
+func TestPublishNeverBlocksAndCountsDropped(t *testing.T) {
+	p := &Publisher{queue: make(chan Message, 1)}
+	ctx := context.Background()
+
+	if err := p.Publish(ctx, Message{Host: "a"}); err != nil {
+		t.Fatalf("unexpected error filling the queue: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := p.Publish(ctx, Message{Host: "b"}); err != nil {
+			t.Errorf("Publish on a full queue must not return an error, got: %v", err)
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Publish blocked on a full queue instead of dropping the message")
+	}
+
+	if got := p.DroppedMessages(); got != 1 {
+		t.Fatalf("DroppedMessages() = %d, want 1", got)
+	}
+}
@@ -0,0 +1,58 @@
+package tholaerr
+
+import "errors"
+
+// ErrNotImplemented indicates that the requested functionality has no
+// implementation at all for this communicator, as opposed to
+// ErrPropertyNotModeled, which means the implementation exists but this
+// particular device class doesn't declare the property.
+type ErrNotImplemented struct {
+	Message string
+}
+
+func (e *ErrNotImplemented) Error() string {
+	return e.Message
+}
+
+func (e *ErrNotImplemented) Is(target error) bool {
+	_, ok := target.(*ErrNotImplemented)
+	return ok
+}
+
+// ErrNotFound indicates that a requested SNMP OID does not exist on the
+// device (noSuchObject/noSuchInstance/endOfMibView).
+type ErrNotFound struct {
+	Message string
+}
+
+func (e *ErrNotFound) Error() string {
+	return e.Message
+}
+
+func (e *ErrNotFound) Is(target error) bool {
+	_, ok := target.(*ErrNotFound)
+	return ok
+}
+
+// NewNotImplementedError creates an ErrNotImplemented with the given message.
+func NewNotImplementedError(message string) error {
+	return &ErrNotImplemented{Message: message}
+}
+
+// NewNotFoundError creates an ErrNotFound with the given message.
+func NewNotFoundError(message string) error {
+	return &ErrNotFound{Message: message}
+}
+
+// IsNotImplementedError reports whether err is, or wraps, an
+// ErrNotImplemented.
+func IsNotImplementedError(err error) bool {
+	var target *ErrNotImplemented
+	return errors.As(err, &target)
+}
+
+// IsNotFoundError reports whether err is, or wraps, an ErrNotFound.
+func IsNotFoundError(err error) bool {
+	var target *ErrNotFound
+	return errors.As(err, &target)
+}
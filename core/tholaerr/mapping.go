@@ -0,0 +1,49 @@
+package tholaerr
+
+import "net/http"
+
+// Check-plugin exit/state codes, matching the Nagios/Icinga convention check
+// plugins across thola are built on.
+const (
+	CheckPluginStateOK       = 0
+	CheckPluginStateWarning  = 1
+	CheckPluginStateCritical = 2
+	CheckPluginStateUnknown  = 3
+)
+
+// HTTPStatusCode maps a typed error to the status code the REST layer
+// should respond with: 404 when the caller asked about a property the
+// device class simply doesn't model, 502 when the device itself failed to
+// answer, 501 when the functionality has no implementation at all, and 500
+// for anything else, including ErrValueCoercion and untyped errors.
+func HTTPStatusCode(err error) int {
+	switch {
+	case err == nil:
+		return http.StatusOK
+	case IsPropertyNotModeledError(err), IsSNMPNoSuchObjectError(err):
+		return http.StatusNotFound
+	case IsSNMPTimeoutError(err), IsDetectionError(err):
+		return http.StatusBadGateway
+	case IsNotImplementedError(err):
+		return http.StatusNotImplemented
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// CheckPluginState maps a typed error to the state a monitoring check
+// plugin should exit with. A property that isn't modeled or implemented is
+// UNKNOWN (a configuration problem, not a fault on the device); an actual
+// failure to read the device is CRITICAL.
+func CheckPluginState(err error) int {
+	switch {
+	case err == nil:
+		return CheckPluginStateOK
+	case IsPropertyNotModeledError(err), IsNotImplementedError(err):
+		return CheckPluginStateUnknown
+	case IsSNMPTimeoutError(err), IsSNMPNoSuchObjectError(err), IsDetectionError(err), IsValueCoercionError(err):
+		return CheckPluginStateCritical
+	default:
+		return CheckPluginStateUnknown
+	}
+}
@@ -0,0 +1,194 @@
+package tholaerr
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrSNMPTimeout indicates that an SNMP request did not receive a response
+// before the configured timeout elapsed, as opposed to the device
+// responding that the OID doesn't exist (see ErrSNMPNoSuchObject).
+type ErrSNMPTimeout struct {
+	Fields map[string]interface{}
+	Cause  error
+}
+
+func (e *ErrSNMPTimeout) Error() string {
+	return "snmp request timed out" + formatFields(e.Fields) + formatCause(e.Cause)
+}
+
+func (e *ErrSNMPTimeout) Unwrap() error { return e.Cause }
+
+// Is reports whether target is also an *ErrSNMPTimeout, ignoring Fields, so
+// errors.Is(err, &tholaerr.ErrSNMPTimeout{}) works regardless of which
+// property/OID the error carries.
+func (e *ErrSNMPTimeout) Is(target error) bool {
+	_, ok := target.(*ErrSNMPTimeout)
+	return ok
+}
+
+// ErrSNMPNoSuchObject indicates that the device responded but does not have
+// the requested OID (SNMP noSuchObject/noSuchInstance/endOfMibView).
+type ErrSNMPNoSuchObject struct {
+	Fields map[string]interface{}
+	Cause  error
+}
+
+func (e *ErrSNMPNoSuchObject) Error() string {
+	return "oid does not exist on device" + formatFields(e.Fields) + formatCause(e.Cause)
+}
+
+func (e *ErrSNMPNoSuchObject) Unwrap() error { return e.Cause }
+
+func (e *ErrSNMPNoSuchObject) Is(target error) bool {
+	_, ok := target.(*ErrSNMPNoSuchObject)
+	return ok
+}
+
+// ErrPropertyNotModeled indicates that the matched device class doesn't
+// declare the requested property at all, as opposed to the property being
+// declared but unreadable on this particular device.
+type ErrPropertyNotModeled struct {
+	Fields map[string]interface{}
+}
+
+func (e *ErrPropertyNotModeled) Error() string {
+	return "property is not modeled for this device class" + formatFields(e.Fields)
+}
+
+func (e *ErrPropertyNotModeled) Is(target error) bool {
+	_, ok := target.(*ErrPropertyNotModeled)
+	return ok
+}
+
+// ErrValueCoercion indicates that a value was read from the device but
+// could not be converted into the type the caller requested (e.g. int,
+// float64, bool).
+type ErrValueCoercion struct {
+	Fields map[string]interface{}
+	Cause  error
+}
+
+func (e *ErrValueCoercion) Error() string {
+	return "failed to coerce value" + formatFields(e.Fields) + formatCause(e.Cause)
+}
+
+func (e *ErrValueCoercion) Unwrap() error { return e.Cause }
+
+func (e *ErrValueCoercion) Is(target error) bool {
+	_, ok := target.(*ErrValueCoercion)
+	return ok
+}
+
+// ErrDetection indicates that a property the device class declares could
+// not be read from the device, e.g. an identify property or a UPS metric.
+type ErrDetection struct {
+	Fields map[string]interface{}
+	Cause  error
+}
+
+func (e *ErrDetection) Error() string {
+	return "device class detection failed" + formatFields(e.Fields) + formatCause(e.Cause)
+}
+
+func (e *ErrDetection) Unwrap() error { return e.Cause }
+
+func (e *ErrDetection) Is(target error) bool {
+	_, ok := target.(*ErrDetection)
+	return ok
+}
+
+// NewSNMPTimeoutError creates an ErrSNMPTimeout carrying the given context
+// fields (e.g. "oid", "property", "device_class") and, if non-nil, the
+// underlying SNMP client error as its cause.
+func NewSNMPTimeoutError(fields map[string]interface{}, cause error) error {
+	return &ErrSNMPTimeout{Fields: fields, Cause: cause}
+}
+
+// NewSNMPNoSuchObjectError creates an ErrSNMPNoSuchObject carrying the given
+// context fields and, if non-nil, the underlying SNMP client error as its
+// cause.
+func NewSNMPNoSuchObjectError(fields map[string]interface{}, cause error) error {
+	return &ErrSNMPNoSuchObject{Fields: fields, Cause: cause}
+}
+
+// NewPropertyNotModeledError creates an ErrPropertyNotModeled carrying the
+// given context fields.
+func NewPropertyNotModeledError(fields map[string]interface{}) error {
+	return &ErrPropertyNotModeled{Fields: fields}
+}
+
+// NewValueCoercionError creates an ErrValueCoercion carrying the given
+// context fields and, if non-nil, the underlying conversion error as its
+// cause.
+func NewValueCoercionError(fields map[string]interface{}, cause error) error {
+	return &ErrValueCoercion{Fields: fields, Cause: cause}
+}
+
+// NewDetectionError creates an ErrDetection carrying the given context
+// fields and, if non-nil, the underlying error as its cause.
+func NewDetectionError(fields map[string]interface{}, cause error) error {
+	return &ErrDetection{Fields: fields, Cause: cause}
+}
+
+// IsSNMPTimeoutError reports whether err is, or wraps, an ErrSNMPTimeout.
+func IsSNMPTimeoutError(err error) bool {
+	var target *ErrSNMPTimeout
+	return errors.As(err, &target)
+}
+
+// IsSNMPNoSuchObjectError reports whether err is, or wraps, an
+// ErrSNMPNoSuchObject.
+func IsSNMPNoSuchObjectError(err error) bool {
+	var target *ErrSNMPNoSuchObject
+	return errors.As(err, &target)
+}
+
+// IsPropertyNotModeledError reports whether err is, or wraps, an
+// ErrPropertyNotModeled.
+func IsPropertyNotModeledError(err error) bool {
+	var target *ErrPropertyNotModeled
+	return errors.As(err, &target)
+}
+
+// IsValueCoercionError reports whether err is, or wraps, an
+// ErrValueCoercion.
+func IsValueCoercionError(err error) bool {
+	var target *ErrValueCoercion
+	return errors.As(err, &target)
+}
+
+// IsDetectionError reports whether err is, or wraps, an ErrDetection.
+func IsDetectionError(err error) bool {
+	var target *ErrDetection
+	return errors.As(err, &target)
+}
+
+// formatCause renders a typed error's wrapped cause as ": <cause>", or "" if
+// there is none.
+func formatCause(cause error) string {
+	if cause == nil {
+		return ""
+	}
+	return ": " + cause.Error()
+}
+
+// formatFields renders a typed error's Fields as " (key=value, ...)",
+// sorted by key so Error() output is deterministic, or "" if empty.
+func formatFields(fields map[string]interface{}) string {
+	if len(fields) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%v", k, fields[k]))
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
@@ -0,0 +1,85 @@
+package tholaerr
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestTypedErrorsIsAs(t *testing.T) {
+	cause := errors.New("underlying failure")
+
+	tests := []struct {
+		name string
+		err  error
+		is   func(error) bool
+	}{
+		{"SNMPTimeout", NewSNMPTimeoutError(nil, cause), IsSNMPTimeoutError},
+		{"SNMPNoSuchObject", NewSNMPNoSuchObjectError(nil, cause), IsSNMPNoSuchObjectError},
+		{"PropertyNotModeled", NewPropertyNotModeledError(nil), IsPropertyNotModeledError},
+		{"ValueCoercion", NewValueCoercionError(nil, cause), IsValueCoercionError},
+		{"Detection", NewDetectionError(nil, cause), IsDetectionError},
+		{"NotImplemented", NewNotImplementedError("nope"), IsNotImplementedError},
+		{"NotFound", NewNotFoundError("nope"), IsNotFoundError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.is(tt.err) {
+				t.Fatalf("expected %v to be recognized as %s", tt.err, tt.name)
+			}
+			// An unrelated error that merely repeats the message text must
+			// not be recognized.
+			wrapped := errors.New("wrap: " + tt.err.Error())
+			if tt.is(wrapped) {
+				t.Fatalf("unrelated error %v should not be recognized as %s", wrapped, tt.name)
+			}
+		})
+	}
+}
+
+func TestTypedErrorsUnwrap(t *testing.T) {
+	cause := errors.New("underlying failure")
+	err := NewDetectionError(map[string]interface{}{"property": "vendor"}, cause)
+	if !errors.Is(err, cause) {
+		t.Fatalf("expected errors.Is to see through ErrDetection to its cause")
+	}
+}
+
+func TestHTTPStatusCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{nil, http.StatusOK},
+		{NewPropertyNotModeledError(nil), http.StatusNotFound},
+		{NewSNMPNoSuchObjectError(nil, nil), http.StatusNotFound},
+		{NewSNMPTimeoutError(nil, nil), http.StatusBadGateway},
+		{NewDetectionError(nil, nil), http.StatusBadGateway},
+		{NewNotImplementedError("nope"), http.StatusNotImplemented},
+		{errors.New("unexpected"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		if got := HTTPStatusCode(tt.err); got != tt.want {
+			t.Errorf("HTTPStatusCode(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestCheckPluginState(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{nil, CheckPluginStateOK},
+		{NewPropertyNotModeledError(nil), CheckPluginStateUnknown},
+		{NewNotImplementedError("nope"), CheckPluginStateUnknown},
+		{NewSNMPTimeoutError(nil, nil), CheckPluginStateCritical},
+		{NewSNMPNoSuchObjectError(nil, nil), CheckPluginStateCritical},
+	}
+	for _, tt := range tests {
+		if got := CheckPluginState(tt.err); got != tt.want {
+			t.Errorf("CheckPluginState(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
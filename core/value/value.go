@@ -0,0 +1,45 @@
+// Package value provides the generic, wire-format-agnostic representation
+// that SNMP-derived readings are carried in until a caller coerces them into
+// the concrete type it actually needs.
+package value
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// OID is a dotted SNMP object identifier, e.g. "1.3.6.1.2.1.1.1.0".
+type OID string
+
+// Value is a generic SNMP-derived reading. It is carried as its string
+// representation and coerced on demand via String/Int/Float64/Bool, so
+// callers that only ever want one of those don't need to know how the
+// underlying SNMP type was decoded.
+type Value string
+
+// New wraps an already-decoded SNMP value (string, int64, float64, ...) as a
+// Value.
+func New(raw interface{}) Value {
+	return Value(fmt.Sprint(raw))
+}
+
+// String returns the value as-is.
+func (v Value) String() string {
+	return string(v)
+}
+
+// Int coerces the value to an int.
+func (v Value) Int() (int, error) {
+	return strconv.Atoi(strings.TrimSpace(string(v)))
+}
+
+// Float64 coerces the value to a float64.
+func (v Value) Float64() (float64, error) {
+	return strconv.ParseFloat(strings.TrimSpace(string(v)), 64)
+}
+
+// Bool coerces the value to a bool.
+func (v Value) Bool() (bool, error) {
+	return strconv.ParseBool(strings.TrimSpace(string(v)))
+}